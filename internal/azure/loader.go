@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -55,6 +56,98 @@ func GetApiVersions(resourceType string) []string {
 	return res
 }
 
+// GetNewerApiVersions returns the api-versions of the resource type which are released after the given one, a stable
+// api-version is newer than the preview api-version of the same date. The preview api-versions are only returned for
+// a preview api-version, they aren't upgrades of a stable one. The result is sorted from the oldest to the newest.
+func GetNewerApiVersions(resourceType string, apiVersion string) []string {
+	preview := IsPreviewApiVersion(apiVersion)
+	res := make([]string, 0)
+	for _, v := range GetApiVersions(resourceType) {
+		if compareApiVersions(v, apiVersion) > 0 && (preview || !IsPreviewApiVersion(v)) {
+			res = append(res, v)
+		}
+	}
+	slices.SortFunc(res, compareApiVersions)
+	return res
+}
+
+// LatestApiVersion returns the latest stable api-version, or the latest preview api-version if there's no stable one
+func LatestApiVersion(apiVersions []string) string {
+	latest := ""
+	for _, v := range apiVersions {
+		switch {
+		case latest == "":
+			latest = v
+		case IsPreviewApiVersion(latest) && !IsPreviewApiVersion(v):
+			latest = v
+		case IsPreviewApiVersion(latest) == IsPreviewApiVersion(v) && compareApiVersions(v, latest) >= 0:
+			latest = v
+		}
+	}
+	return latest
+}
+
+// compareApiVersions compares the api-versions by their dates, a stable api-version is newer than the preview
+// api-version of the same date
+func compareApiVersions(a, b string) int {
+	if c := strings.Compare(apiVersionDate(a), apiVersionDate(b)); c != 0 {
+		return c
+	}
+	switch previewA, previewB := IsPreviewApiVersion(a), IsPreviewApiVersion(b); {
+	case previewA == previewB:
+		return strings.Compare(a, b)
+	case previewA:
+		return -1
+	}
+	return 1
+}
+
+func IsPreviewApiVersion(apiVersion string) bool {
+	return strings.Contains(strings.ToLower(apiVersion), "preview")
+}
+
+// apiVersionDate returns the date part of the api-version, e.g. 2024-01-01 for 2024-01-01-preview
+func apiVersionDate(apiVersion string) string {
+	if len(apiVersion) > len("2006-01-02") {
+		return apiVersion[0:len("2006-01-02")]
+	}
+	return apiVersion
+}
+
+// GetResourceType returns the resource type defined in the schema which equals to the given one ignoring case,
+// it returns an empty string if the resource type is not found. When the schema defines the resource type
+// in different cases, the one with the most api-versions is returned.
+func GetResourceType(resourceType string) string {
+	azureSchema := GetAzureSchema()
+	if azureSchema == nil {
+		return ""
+	}
+	if _, ok := azureSchema.Resources[resourceType]; ok {
+		return resourceType
+	}
+	res := ""
+	for key, value := range azureSchema.Resources {
+		if !strings.EqualFold(key, resourceType) {
+			continue
+		}
+		if res == "" {
+			res = key
+			continue
+		}
+		count, resCount := 0, 0
+		if value != nil {
+			count = len(value.Definitions)
+		}
+		if azureSchema.Resources[res] != nil {
+			resCount = len(azureSchema.Resources[res].Definitions)
+		}
+		if count > resCount || (count == resCount && key < res) {
+			res = key
+		}
+	}
+	return res
+}
+
 func GetResourceDefinitionByResourceType(azureResourceType string) (*types.ResourceType, error) {
 	parts := strings.Split(azureResourceType, "@")
 	if len(parts) != 2 {
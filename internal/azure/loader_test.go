@@ -1,6 +1,7 @@
 package azure_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/Azure/ms-terraform-lsp/internal/azure"
@@ -86,3 +87,77 @@ func Test_AllBicepTypes(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetNewerApiVersions(t *testing.T) {
+	resourceType := "Microsoft.MachineLearningServices/workspaces/computes"
+	versions := azure.GetApiVersions(resourceType)
+	if len(versions) < 2 {
+		t.Fatalf("expect multiple api-versions for %s", resourceType)
+	}
+
+	if newer := azure.GetNewerApiVersions(resourceType, versions[len(versions)-1]); len(newer) != 0 {
+		t.Errorf("expect no newer api-version than %s but got %v", versions[len(versions)-1], newer)
+	}
+	if newer := azure.GetNewerApiVersions(resourceType, "2000-01-01-preview"); len(newer) != len(versions) {
+		t.Errorf("expect %d newer api-versions than 2000-01-01-preview but got %d", len(versions), len(newer))
+	}
+
+	testcases := []struct {
+		ResourceType string
+		ApiVersion   string
+		Expected     []string
+	}{
+		{
+			// the stable api-version of the same date is newer than the preview one
+			ResourceType: resourceType,
+			ApiVersion:   "2025-04-01-preview",
+			Expected:     []string{"2025-04-01", "2025-06-01"},
+		},
+		{
+			// the preview api-versions aren't upgrades of a stable api-version
+			ResourceType: resourceType,
+			ApiVersion:   "2024-10-01",
+			Expected:     []string{"2025-04-01", "2025-06-01"},
+		},
+		{
+			ResourceType: "Microsoft.AlertsManagement/alerts",
+			ApiVersion:   "2019-03-01",
+			Expected:     []string{},
+		},
+	}
+	for _, tc := range testcases {
+		if actual := azure.GetNewerApiVersions(tc.ResourceType, tc.ApiVersion); !reflect.DeepEqual(actual, tc.Expected) {
+			t.Errorf("expect %v newer than %s@%s but got %v", tc.Expected, tc.ResourceType, tc.ApiVersion, actual)
+		}
+	}
+}
+
+func Test_LatestApiVersion(t *testing.T) {
+	testcases := []struct {
+		Input    []string
+		Expected string
+	}{
+		{
+			Input:    []string{"2023-01-01", "2024-01-01-preview", "2024-01-01", "2024-05-01-preview"},
+			Expected: "2024-01-01",
+		},
+		{
+			Input:    []string{"2024-01-01", "2024-01-01-preview"},
+			Expected: "2024-01-01",
+		},
+		{
+			Input:    []string{"2023-01-01-preview", "2024-05-01-preview"},
+			Expected: "2024-05-01-preview",
+		},
+		{
+			Input:    []string{},
+			Expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		if actual := azure.LatestApiVersion(tc.Input); actual != tc.Expected {
+			t.Errorf("expect %s for %v but got %s", tc.Expected, tc.Input, actual)
+		}
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/Azure/ms-terraform-lsp/internal/filesystem"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/diagnostics"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
 	"github.com/Azure/ms-terraform-lsp/internal/telemetry"
+	"github.com/Azure/ms-terraform-lsp/internal/workspace"
 )
 
 type contextKey struct {
@@ -22,6 +24,8 @@ var (
 	ctxLsVersion     = &contextKey{"language server version"}
 	ctxClientCaller  = &contextKey{Name: "client caller"}
 	ctxTelemetry     = &contextKey{"telemetry"}
+	ctxSettings      = &contextKey{"settings"}
+	ctxWorkspace     = &contextKey{"workspace folders"}
 )
 
 func missingContextErr(ctxKey *contextKey) *MissingContextErr {
@@ -104,3 +108,29 @@ func Telemetry(ctx context.Context) (telemetry.Sender, error) {
 
 	return tel, nil
 }
+
+func WithSettings(ctx context.Context, s settings.Settings) context.Context {
+	return context.WithValue(ctx, ctxSettings, s)
+}
+
+func Settings(ctx context.Context) (settings.Settings, error) {
+	s, ok := ctx.Value(ctxSettings).(settings.Settings)
+	if !ok {
+		return settings.Settings{}, missingContextErr(ctxSettings)
+	}
+
+	return s, nil
+}
+
+func WithWorkspaceFolders(ctx context.Context, folders *workspace.Folders) context.Context {
+	return context.WithValue(ctx, ctxWorkspace, folders)
+}
+
+func WorkspaceFolders(ctx context.Context) (*workspace.Folders, error) {
+	folders, ok := ctx.Value(ctxWorkspace).(*workspace.Folders)
+	if !ok {
+		return nil, missingContextErr(ctxWorkspace)
+	}
+
+	return folders, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"path/filepath"
 
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
 	"github.com/Azure/ms-terraform-lsp/internal/source"
 	"github.com/Azure/ms-terraform-lsp/internal/uri"
 	"github.com/spf13/afero"
@@ -42,6 +43,23 @@ func (d *document) Version() int {
 	return d.meta.Version()
 }
 
+// ParsedFile returns the parsed content of the document, the content is parsed once per version and shared by
+// the requests, so the result must not be modified
+func (d *document) ParsedFile() (*parser.File, error) {
+	// the version is read before the content, so an older content is never kept for a newer version
+	version := d.meta.Version()
+	if file := d.meta.parsedFile(version); file != nil {
+		return file, nil
+	}
+	src, err := d.Text()
+	if err != nil {
+		return nil, err
+	}
+	file := parser.ParseFile(src, d.Filename())
+	d.meta.setParsedFile(version, file)
+	return file, nil
+}
+
 func (d *document) LanguageID() string {
 	return d.meta.langId
 }
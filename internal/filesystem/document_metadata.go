@@ -3,6 +3,7 @@ package filesystem
 import (
 	"sync"
 
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
 	"github.com/Azure/ms-terraform-lsp/internal/source"
 )
 
@@ -14,6 +15,10 @@ type documentMetadata struct {
 	version int
 	langId  string
 	lines   source.Lines
+
+	// parsed is the parsed content of the version parsedVersion, it's dropped when the content is changed
+	parsed        *parser.File
+	parsedVersion int
 }
 
 func NewDocumentMetadata(dh DocumentHandler, langId string, content []byte) *documentMetadata {
@@ -41,6 +46,7 @@ func (d *documentMetadata) updateLines(content []byte) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.lines = source.MakeSourceLines(d.dh.Filename(), content)
+	d.parsed = nil
 }
 
 func (d *documentMetadata) Lines() source.Lines {
@@ -60,3 +66,24 @@ func (d *documentMetadata) IsOpen() bool {
 	defer d.mu.RUnlock()
 	return d.isOpen
 }
+
+// parsedFile returns the parsed content of the version, or nil if it isn't parsed yet
+func (d *documentMetadata) parsedFile(version int) *parser.File {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.parsed == nil || d.parsedVersion != version {
+		return nil
+	}
+	return d.parsed
+}
+
+// setParsedFile keeps the parsed content of the version, it's ignored if the document is changed to another version
+func (d *documentMetadata) setParsedFile(version int, file *parser.File) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.version != version {
+		return
+	}
+	d.parsed = file
+	d.parsedVersion = version
+}
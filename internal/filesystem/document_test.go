@@ -39,6 +39,50 @@ func TestFile_ApplyChange_fullUpdate(t *testing.T) {
 	}
 }
 
+func TestFile_ParsedFile_cachedPerVersion(t *testing.T) {
+	fs := testDocumentStorage()
+	dh := &testHandler{uri: "file:///test.tf", fullPath: "/test.tf"}
+
+	err := fs.CreateAndOpenDocument(dh, "terraform", []byte(`locals { a = 1 }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := fs.GetDocument(dh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := doc.ParsedFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := doc.ParsedFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatal("expected the parsed file is reused for the same version")
+	}
+
+	err = fs.ChangeDocument(dh, []DocumentChange{&testChange{text: `locals { b = 2 }`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err = fs.GetDocument(dh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := doc.ParsedFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed == first {
+		t.Fatal("expected the parsed file is dropped after the change")
+	}
+	if diff := cmp.Diff(`locals { b = 2 }`, string(changed.Src)); diff != "" {
+		t.Fatalf("content mismatch: %s", diff)
+	}
+}
+
 func TestFile_ApplyChange_partialUpdate(t *testing.T) {
 	testData := []struct {
 		Name       string
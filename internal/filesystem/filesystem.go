@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"log"
 	"os"
+	"sort"
 	"sync"
 
 	"github.com/Azure/ms-terraform-lsp/internal/source"
@@ -198,6 +199,29 @@ func (fs *fsystem) GetDocument(dh DocumentHandler) (Document, error) {
 	}, nil
 }
 
+// OpenDocuments returns the documents which are open in the client, ordered by their URIs
+func (fs *fsystem) OpenDocuments() []Document {
+	fs.docMetaMu.RLock()
+	defer fs.docMetaMu.RUnlock()
+
+	uris := make([]string, 0, len(fs.docMeta))
+	for u, dm := range fs.docMeta {
+		if dm.IsOpen() {
+			uris = append(uris, u)
+		}
+	}
+	sort.Strings(uris)
+
+	docs := make([]Document, 0, len(uris))
+	for _, u := range uris {
+		docs = append(docs, &document{
+			meta: fs.docMeta[u],
+			fs:   fs.memFs,
+		})
+	}
+	return docs
+}
+
 func (fs *fsystem) ReadFile(name string) ([]byte, error) {
 	b, err := afero.ReadFile(fs.memFs, name)
 	if err != nil && os.IsNotExist(err) {
@@ -163,6 +163,29 @@ func TestFilesystem_GetDocument_unknownDocument(t *testing.T) {
 	}
 }
 
+func TestFilesystem_OpenDocuments(t *testing.T) {
+	fs := testDocumentStorage()
+
+	for _, path := range []string{"/b.tf", "/a.tf"} {
+		err := fs.CreateAndOpenDocument(testHandlerFromPath(path), "test", []byte("hello world"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := fs.CreateDocument(testHandlerFromPath("/c.tf"), "test", []byte("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make([]string, 0)
+	for _, doc := range fs.OpenDocuments() {
+		paths = append(paths, doc.FullPath())
+	}
+	if diff := cmp.Diff([]string{"/a.tf", "/b.tf"}, paths); diff != "" {
+		t.Fatalf("Open documents don't match: %s", diff)
+	}
+}
+
 func TestFilesystem_ReadFile_osOnly(t *testing.T) {
 	tmpDir := TempDir(t)
 	f, err := os.Create(filepath.Join(tmpDir, "testfile"))
@@ -5,12 +5,14 @@ import (
 	"log"
 	"os"
 
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
 	"github.com/Azure/ms-terraform-lsp/internal/source"
 )
 
 type Document interface {
 	DocumentHandler
 	Text() ([]byte, error)
+	ParsedFile() (*parser.File, error)
 	Lines() source.Lines
 	LanguageID() string
 	Version() int
@@ -43,6 +45,7 @@ type DocumentStorage interface {
 	CloseAndRemoveDocument(DocumentHandler) error
 	ChangeDocument(VersionedDocumentHandler, DocumentChanges) error
 	HasOpenFiles(path string) (bool, error)
+	OpenDocuments() []Document
 }
 
 type Filesystem interface {
@@ -3,13 +3,16 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"strings"
 
 	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/validate"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 func (h *logHandler) TextDocumentCodeAction(ctx context.Context, params lsp.CodeActionParams) []lsp.CodeAction {
@@ -52,15 +55,13 @@ func (h *logHandler) textDocumentCodeAction(ctx context.Context, params lsp.Code
 		return list, err
 	}
 
-	data, err := doc.Text()
+	file, err := doc.ParsedFile()
 	if err != nil {
 		return list, err
 	}
 
-	hclDoc, _ := hclsyntax.ParseConfig(data, "", hcl.InitialPos)
-
-	body, isHcl := hclDoc.Body.(*hclsyntax.Body)
-	if !isHcl {
+	body := file.Body
+	if body == nil {
 		h.logger.Printf("file is not hcl")
 		return list, nil
 	}
@@ -94,11 +95,131 @@ func (h *logHandler) textDocumentCodeAction(ctx context.Context, params lsp.Code
 		}
 	}
 
+	settings, err := lsctx.Settings(ctx)
+	if err != nil {
+		return list, err
+	}
+	folders, err := lsctx.WorkspaceFolders(ctx)
+	if err != nil {
+		return list, err
+	}
+	opts := folders.Settings(settings, doc.FullPath()).ValidationOptions()
+	list = append(list, listCodeActionForQuickFixes(params, file, opts)...)
+	list = append(list, listCodeActionForFixAll(params, file, opts)...)
 	list = append(list, listCodeActionForGeneratingPermission(params, hasAzapiForGeneratingPermission, hasAzurermForGeneratingPermission)...)
 	list = append(list, listCodeActionForMigratingResources(params, hasAzapiResources, hasAzurermResources)...)
 	return list, nil
 }
 
+// listCodeActionForQuickFixes returns the quick fixes of the schema validation diagnostics in the given range
+func listCodeActionForQuickFixes(params lsp.CodeActionParams, file *parser.File, opts rules.Options) []lsp.CodeAction {
+	if len(params.Context.Only) != 0 && !ilsp.SupportedCodeActions.Only(params.Context.Only)[lsp.QuickFix] {
+		return nil
+	}
+
+	_, diags := validate.ValidateParsedFile(file, opts)
+	res := make([]lsp.CodeAction, 0)
+	for _, diag := range diags {
+		fix := validate.DiagnosticFix(diag)
+		if fix == nil || diag.Subject == nil || !rangeOverlaps(ilsp.HCLRangeToLSP(*diag.Subject), params.Range) {
+			continue
+		}
+		res = append(res, lsp.CodeAction{
+			Title:       fix.Title,
+			Kind:        lsp.QuickFix,
+			Diagnostics: ilsp.HCLDiagsToLSP(hcl.Diagnostics{diag}, validate.DiagnosticSource),
+			IsPreferred: true,
+			Edit: lsp.WorkspaceEdit{
+				Changes: map[string][]lsp.TextEdit{
+					string(params.TextDocument.URI): textEdits(fixEdits(fix)),
+				},
+			},
+		})
+	}
+	return res
+}
+
+// listCodeActionForFixAll returns the code action which applies all the unambiguous fixes in the file,
+// it's only returned when it's explicitly requested, e.g. on save
+func listCodeActionForFixAll(params lsp.CodeActionParams, file *parser.File, opts rules.Options) []lsp.CodeAction {
+	if len(params.Context.Only) == 0 || !ilsp.SupportedCodeActions.Only(params.Context.Only)[ilsp.SourceFixAllAzure] {
+		return nil
+	}
+
+	_, diags := validate.ValidateParsedFile(file, opts)
+	fixedDiags := make(hcl.Diagnostics, 0)
+	fixes := make([]*validate.Fix, 0)
+	for _, diag := range diags {
+		if fix := validate.DiagnosticFix(diag); fix != nil && fix.Unambiguous {
+			fixedDiags = append(fixedDiags, diag)
+			fixes = append(fixes, fix)
+		}
+	}
+	edits := fixAllEdits(fixes)
+	if len(edits) == 0 {
+		return nil
+	}
+
+	return []lsp.CodeAction{
+		{
+			Title:       "Fix all unambiguous schema validation errors",
+			Kind:        ilsp.SourceFixAllAzure,
+			Diagnostics: ilsp.HCLDiagsToLSP(fixedDiags, validate.DiagnosticSource),
+			Edit: lsp.WorkspaceEdit{
+				Changes: map[string][]lsp.TextEdit{
+					string(params.TextDocument.URI): edits,
+				},
+			},
+		},
+	}
+}
+
+// fixAllEdits returns the edits of the fixes ordered by their ranges. The edits in one workspace edit must not
+// overlap, so a fix is skipped with all its edits if any of them overlaps with the edits of the previous fixes.
+func fixAllEdits(fixes []*validate.Fix) []lsp.TextEdit {
+	sort.SliceStable(fixes, func(i, j int) bool {
+		return fixes[i].Range.Start.Byte < fixes[j].Range.Start.Byte
+	})
+
+	accepted := make([]validate.Edit, 0)
+	for _, fix := range fixes {
+		edits := fixEdits(fix)
+		if !editsOverlap(accepted, edits) {
+			accepted = append(accepted, edits...)
+		}
+	}
+	return textEdits(accepted)
+}
+
+// fixEdits returns the edit which replaces the range of the fix, followed by the additional edits
+func fixEdits(fix *validate.Fix) []validate.Edit {
+	return append([]validate.Edit{{Range: fix.Range, NewText: fix.NewText}}, fix.AdditionalEdits...)
+}
+
+// editsOverlap returns true if any edits in a and b overlap, the edits starting at the same position are also
+// regarded as overlapping, because the order to apply them is ambiguous
+func editsOverlap(a []validate.Edit, b []validate.Edit) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Range.Start.Byte == y.Range.Start.Byte || (x.Range.Start.Byte < y.Range.End.Byte && y.Range.Start.Byte < x.Range.End.Byte) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func textEdits(edits []validate.Edit) []lsp.TextEdit {
+	res := make([]lsp.TextEdit, 0, len(edits))
+	for _, edit := range edits {
+		res = append(res, lsp.TextEdit{
+			Range:   ilsp.HCLRangeToLSP(edit.Range),
+			NewText: edit.NewText,
+		})
+	}
+	return res
+}
+
 func listCodeActionForGeneratingPermission(params lsp.CodeActionParams, hasAzapi bool, hasAzurerm bool) []lsp.CodeAction {
 	if !hasAzapi && !hasAzurerm {
 		return nil
@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/validate"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
 	"github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl/v2"
 )
 
 func TestCodeAction_withoutInitialization(t *testing.T) {
@@ -158,6 +161,138 @@ func TestCodeAction_migrateToAzapi(t *testing.T) {
 	}, string(expectRaw))
 }
 
+func TestCodeAction_quickFix(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectRaw, err := os.ReadFile(fmt.Sprintf("./testdata/%s/expect.json", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reqParams := buildReqParamsCodeAction(6, 7, 6, 7, tmpDir.URI())
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/codeAction",
+		ReqParams: reqParams,
+	}, string(expectRaw))
+}
+
+func TestCodeAction_fixAll(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectRaw, err := os.ReadFile(fmt.Sprintf("./testdata/%s/expect.json", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reqParams := fmt.Sprintf(`{
+		"textDocument": {
+			"uri": "%s/main.tf"
+		},
+		"range": {
+			"start": {"line": 0, "character": 0},
+			"end": {"line": 0, "character": 0}
+		},
+		"context": {
+			"diagnostics": [],
+			"only": ["source.fixAll"]
+		}
+	}`, tmpDir.URI())
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/codeAction",
+		ReqParams: reqParams,
+	}, string(expectRaw))
+}
+
+func Test_fixAllEdits(t *testing.T) {
+	byteRange := func(start, end int) hcl.Range {
+		return hcl.Range{
+			Start: hcl.Pos{Line: 1, Column: start + 1, Byte: start},
+			End:   hcl.Pos{Line: 1, Column: end + 1, Byte: end},
+		}
+	}
+	fixes := []*validate.Fix{
+		{Range: byteRange(20, 25), NewText: "c", Unambiguous: true},
+		{
+			Range:           byteRange(0, 5),
+			NewText:         "a",
+			Unambiguous:     true,
+			AdditionalEdits: []validate.Edit{{Range: byteRange(30, 30), NewText: "a2"}},
+		},
+		// the additional edit overlaps with the previous fix, so none of its edits is applied
+		{
+			Range:           byteRange(10, 15),
+			NewText:         "b",
+			Unambiguous:     true,
+			AdditionalEdits: []validate.Edit{{Range: byteRange(2, 2), NewText: "b2"}},
+		},
+	}
+
+	edits := fixAllEdits(fixes)
+	actual := make([]string, 0)
+	for _, edit := range edits {
+		actual = append(actual, edit.NewText)
+	}
+	if expected := []string{"a", "a2", "c"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected edits %v, got %v", expected, actual)
+	}
+}
+
 func buildReqParamsCodeAction(startLine, startCharacter, endLine, endCharacter int, uri string) string {
 	param := protocol.CodeActionParams{
 		TextDocument: protocol.TextDocumentIdentifier{
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/command"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func (svc *service) TextDocumentCodeLens(ctx context.Context, params lsp.CodeLensParams) ([]lsp.CodeLens, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := doc.ParsedFile()
+	if err != nil {
+		return nil, err
+	}
+
+	body := file.Body
+	if body == nil {
+		return []lsp.CodeLens{}, nil
+	}
+
+	codeLenses := make([]lsp.CodeLens, 0)
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 {
+			continue
+		}
+		if block.Labels[0] != "azapi_resource" && block.Labels[0] != "azapi_update_resource" {
+			continue
+		}
+		codeLenses = append(codeLenses, apiVersionCodeLenses(params.TextDocument, block)...)
+	}
+	return codeLenses, nil
+}
+
+// apiVersionCodeLenses returns the code lens which shows the newer api-versions of the resource type and upgrades the
+// api-version to the latest one, the title also tells whether the current api-version is a preview version
+func apiVersionCodeLenses(textDocument lsp.TextDocumentIdentifier, block *hclsyntax.Block) []lsp.CodeLens {
+	typeAttribute := parser.AttributeWithName(block, "type")
+	typeValue := parser.ExtractAzureResourceType(block)
+	if typeAttribute == nil || typeValue == nil {
+		return nil
+	}
+	parts := strings.Split(*typeValue, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	resourceType, apiVersion := parts[0], parts[1]
+
+	codeLenses := make([]lsp.CodeLens, 0)
+	r := ilsp.HCLRangeToLSP(block.DefRange())
+	if newer := azure.GetNewerApiVersions(resourceType, apiVersion); len(newer) != 0 {
+		latest := azure.LatestApiVersion(newer)
+		title := fmt.Sprintf("%d newer API versions available (latest: %s)", len(newer), latest)
+		if len(newer) == 1 {
+			title = fmt.Sprintf("1 newer API version available (latest: %s)", latest)
+		}
+		if azure.IsPreviewApiVersion(apiVersion) {
+			title = "Preview API version, " + title
+		}
+
+		argument, _ := json.Marshal(command.UpgradeApiVersionParams{
+			TextDocument: textDocument,
+			Address:      strings.Join(block.Labels, "."),
+			ResourceType: resourceType,
+			ApiVersion:   latest,
+		})
+		codeLenses = append(codeLenses, lsp.CodeLens{
+			Range: r,
+			Command: lsp.Command{
+				Title:     title,
+				Command:   CommandUpgradeApiVersion,
+				Arguments: []json.RawMessage{argument},
+			},
+		})
+	}
+	return codeLenses
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
+)
+
+func TestCodeLens_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, TempDir(t).URI()),
+	}, session.SessionNotInitialized.Err())
+}
+
+func TestCodeLens_apiVersion(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	// the number of newer api-versions grows with the schema, so it's calculated here
+	rgVersions := azure.GetNewerApiVersions("Microsoft.Resources/resourceGroups", "2020-06-01")
+	storageVersions := azure.GetNewerApiVersions("Microsoft.Storage/storageAccounts", "2020-08-01-preview")
+	rgLatest := azure.LatestApiVersion(rgVersions)
+	storageLatest := azure.LatestApiVersion(storageVersions)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI()),
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 30}
+				},
+				"command": {
+					"title": "%[2]d newer API versions available (latest: %[3]s)",
+					"command": "ms-terraform.upgradeApiVersion",
+					"arguments": [
+						{
+							"textDocument": {"uri": "%[1]s/main.tf"},
+							"address": "azapi_resource.rg",
+							"resourceType": "Microsoft.Resources/resourceGroups",
+							"apiVersion": "%[3]s"
+						}
+					]
+				}
+			},
+			{
+				"range": {
+					"start": {"line": 6, "character": 0},
+					"end": {"line": 6, "character": 42}
+				},
+				"command": {
+					"title": "Preview API version, %[4]d newer API versions available (latest: %[5]s)",
+					"command": "ms-terraform.upgradeApiVersion",
+					"arguments": [
+						{
+							"textDocument": {"uri": "%[1]s/main.tf"},
+							"address": "azapi_update_resource.storage",
+							"resourceType": "Microsoft.Storage/storageAccounts",
+							"apiVersion": "%[5]s"
+						}
+					]
+				}
+			}
+		]
+	}`, tmpDir.URI(), len(rgVersions), rgLatest, len(storageVersions), storageLatest))
+}
@@ -13,15 +13,17 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
-	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
 	ictx "github.com/Azure/ms-terraform-lsp/internal/context"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
 	"github.com/Azure/ms-terraform-lsp/internal/parser"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
 	"github.com/Azure/ms-terraform-lsp/internal/tf"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/hcl/v2"
@@ -133,7 +135,7 @@ func (c AztfAuthorizeCommand) Handle(ctx context.Context, arguments []json.RawMe
 
 	actions := make(map[string]struct{}, 0)
 	apiVersionRe := regexp.MustCompile(`^\d{4}\-\d{2}\-\d{2}$`)
-	workingDirectory := getWorkingDirectory(string(params.TextDocument.URI), runtime.GOOS)
+	workingDirectory := workingDirectory(ctx, params.TextDocument.URI)
 	mapping, err := getAzurermMapping(ctx, workingDirectory)
 	if err != nil {
 		return nil, fmt.Errorf("error get azurerm mapping: %+v", err)
@@ -410,7 +412,7 @@ output "permissions" {
 		return nil, fmt.Errorf("writing config file %q: %+v", configFileName, err)
 	}
 
-	terraform, err := tf.NewTerraform(tempDir, false)
+	terraform, err := tf.NewTerraform(tempDir, clientSettings(ctx, tempDir).TerraformPath, false)
 	if err != nil {
 		return nil, fmt.Errorf("creating terraform instance: %+v", err)
 	}
@@ -684,20 +686,53 @@ func normalizeName(input string) string {
 //go:embed data/aztfo_report.json
 var localReportBytes []byte
 
-var mapping map[string]map[string]interface{}
+// azurermMapping is the azurerm mapping loaded for a Terraform working directory, it's shared by the requests
+type azurermMapping struct {
+	// loadMu is held while the mapping is being loaded, so it's only loaded once at a time
+	loadMu sync.Mutex
 
-func getAzurermMapping(ctx context.Context, dir string) (map[string]map[string]interface{}, error) {
-	if mapping != nil {
-		return mapping, nil
+	mu            sync.RWMutex
+	resources     map[string]map[string]interface{}
+	resourceTypes map[string]string
+}
+
+var (
+	azurermMappingsMu sync.Mutex
+	// azurermMappings is keyed by the Terraform working directory, because the report depends on the installed provider
+	azurermMappings = make(map[string]*azurermMapping)
+)
+
+func azurermMappingOf(dir string) *azurermMapping {
+	azurermMappingsMu.Lock()
+	defer azurermMappingsMu.Unlock()
+	m, ok := azurermMappings[dir]
+	if !ok {
+		m = &azurermMapping{}
+		azurermMappings[dir] = m
+	}
+	return m
+}
+
+// loaded returns the loaded mapping and resource types, they're nil if the mapping isn't loaded yet
+func (m *azurermMapping) loaded() (map[string]map[string]interface{}, map[string]string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.resources, m.resourceTypes
+}
+
+// load loads the mapping if it isn't loaded yet, the caller must hold loadMu
+func (m *azurermMapping) load(ctx context.Context, dir string) error {
+	if resources, _ := m.loaded(); resources != nil {
+		return nil
 	}
 
-	mapping = make(map[string]map[string]interface{}, 0)
 	var jsonValue []map[string]interface{}
 	reportBytes := getAztfoReport(ctx, dir)
 	if err := json.Unmarshal(reportBytes, &jsonValue); err != nil {
-		return nil, err
+		return err
 	}
 
+	resources := make(map[string]map[string]interface{}, 0)
 	for _, v := range jsonValue {
 		id := v["id"].(map[string]interface{})
 		blockType := "resource"
@@ -705,15 +740,129 @@ func getAzurermMapping(ctx context.Context, dir string) (map[string]map[string]i
 			blockType = "data"
 		}
 		resourceType := fmt.Sprintf("%v.%v", blockType, id["name"].(string))
-		mapping[resourceType] = v
+		resources[resourceType] = v
 	}
-	return mapping, nil
+	resourceTypes := azurermResourceTypes(resources)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resources = resources
+	m.resourceTypes = resourceTypes
+	return nil
+}
+
+func getAzurermMapping(ctx context.Context, dir string) (map[string]map[string]interface{}, error) {
+	m := azurermMappingOf(dir)
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+	if err := m.load(ctx, dir); err != nil {
+		return nil, err
+	}
+	resources, _ := m.loaded()
+	return resources, nil
+}
+
+// GetAzurermResourceTypes returns the ARM resource types of the azurerm resources and data sources, the key is like
+// resource.azurerm_storage_account and the value is like Microsoft.Storage/storageAccounts.
+// The types are resolved from the GET requests in the azurerm mapping of the Terraform working directory.
+func GetAzurermResourceTypes(ctx context.Context, dir string) (map[string]string, error) {
+	m := azurermMappingOf(dir)
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+	if err := m.load(ctx, dir); err != nil {
+		return nil, err
+	}
+	_, resourceTypes := m.loaded()
+	return resourceTypes, nil
+}
+
+// AzurermResourceTypes returns the ARM resource types like GetAzurermResourceTypes, but it doesn't wait for the azurerm
+// mapping. It returns nil until the mapping of the Terraform working directory is loaded in the background.
+func AzurermResourceTypes(dir string, s settings.Settings) map[string]string {
+	m := azurermMappingOf(dir)
+	if _, resourceTypes := m.loaded(); resourceTypes != nil {
+		return resourceTypes
+	}
+	m.loadInBackground(dir, s)
+	return nil
+}
+
+// LoadAzurermMapping starts loading the azurerm mapping of the Terraform working directory in the background,
+// it does nothing if the mapping is already loaded or being loaded
+func LoadAzurermMapping(dir string, s settings.Settings) {
+	azurermMappingOf(dir).loadInBackground(dir, s)
+}
+
+func (m *azurermMapping) loadInBackground(dir string, s settings.Settings) {
+	if !m.loadMu.TryLock() {
+		return
+	}
+	go func() {
+		defer m.loadMu.Unlock()
+		// the settings decide whether the report is downloaded and which terraform is used
+		ctx := ictx.WithSettings(context.Background(), s)
+		if err := m.load(ctx, dir); err != nil {
+			log.Printf("[ERROR] failed to load the azurerm mapping of %q: %+v", dir, err)
+		}
+	}()
+}
+
+// azurermResourceTypes resolves the ARM resource types from the GET requests in the azurerm mapping
+func azurermResourceTypes(azurermMapping map[string]map[string]interface{}) map[string]string {
+	res := make(map[string]string)
+	for key, v := range azurermMapping {
+		operations, ok := v["read"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range operations {
+			operation, ok := item.(map[string]interface{})
+			if !ok || operation["kind"] != "GET" {
+				continue
+			}
+			path, ok := operation["path"].(string)
+			if !ok {
+				continue
+			}
+			if resourceType := armResourceTypeFromPath(path); resourceType != "" {
+				res[key] = resourceType
+				break
+			}
+		}
+	}
+	return res
+}
+
+// armResourceTypeFromPath converts the upper case path, e.g. /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.NETWORK/VIRTUALNETWORKS/{},
+// to the resource type defined in the azure schema, e.g. Microsoft.Network/virtualNetworks
+func armResourceTypeFromPath(path string) string {
+	if !strings.HasSuffix(path, "/{}") {
+		return ""
+	}
+
+	var resourceType string
+	if index := strings.LastIndex(path, "/PROVIDERS/"); index != -1 {
+		parts := strings.Split(path[index+len("/PROVIDERS/"):], "/")
+		resourceType = parts[0]
+		for i := 1; i < len(parts); i += 2 {
+			resourceType += "/" + parts[i]
+		}
+	} else {
+		parts := strings.Split(path, "/")
+		resourceType = "Microsoft.Resources/" + parts[len(parts)-2]
+	}
+	return azure.GetResourceType(resourceType)
 }
 
 // getAztfoReport get online magodo/aztfo report based on azurerm provider version get from `terraform version` command
-// if the process fails, it will use the local report
+// if the process fails or the offline mode is enabled, it will use the local report
 func getAztfoReport(ctx context.Context, dir string) []byte {
-	terraform, err := tf.NewTerraform(dir, false)
+	if clientSettings(ctx, dir).Offline {
+		log.Printf("[DEBUG] offline mode is enabled, use local azurerm report")
+		return localReportBytes
+	}
+
+	terraform, err := tf.NewTerraform(dir, clientSettings(ctx, dir).TerraformPath, false)
 	if err != nil {
 		log.Printf("[ERROR] failed to run terraform to retrieve installed azurerm provider version: %+v", err)
 		return localReportBytes
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -52,3 +53,66 @@ func Test_getAzurermMapping(t *testing.T) {
 		t.Errorf("error get azurerm mapping: %+v", err)
 	}
 }
+
+func Test_armResourceTypeFromPath(t *testing.T) {
+	testcases := []struct {
+		Path     string
+		Expected string
+	}{
+		{
+			Path:     "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}",
+			Expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			Path:     "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.STORAGE/STORAGEACCOUNTS/{}",
+			Expected: "Microsoft.Storage/storageAccounts",
+		},
+		{
+			Path:     "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.NETWORK/VIRTUALNETWORKS/{}/SUBNETS/{}",
+			Expected: "Microsoft.Network/virtualNetworks/subnets",
+		},
+		{
+			Path:     "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.AZUREACTIVEDIRECTORY/CHECKNAMEAVAILABILITY",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		if actual := armResourceTypeFromPath(tc.Path); actual != tc.Expected {
+			t.Errorf("expected %q for %q, got %q", tc.Expected, tc.Path, actual)
+		}
+	}
+}
+
+func Test_GetAzurermResourceTypes(t *testing.T) {
+	resourceTypes, err := GetAzurermResourceTypes(context.Background(), ".")
+	if err != nil {
+		t.Fatalf("error get azurerm resource types: %+v", err)
+	}
+	if actual := resourceTypes["resource.azurerm_storage_account"]; actual != "Microsoft.Storage/storageAccounts" {
+		t.Errorf("expected Microsoft.Storage/storageAccounts for azurerm_storage_account, got %q", actual)
+	}
+}
+
+func Test_GetAzurermResourceTypes_concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	results := make([]map[string]string, 4)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resourceTypes, err := GetAzurermResourceTypes(context.Background(), ".")
+			if err != nil {
+				t.Errorf("error get azurerm resource types: %+v", err)
+			}
+			results[i] = resourceTypes
+		}(i)
+	}
+	wg.Wait()
+
+	for _, resourceTypes := range results {
+		if len(resourceTypes) != len(results[0]) || resourceTypes["resource.azurerm_storage_account"] != "Microsoft.Storage/storageAccounts" {
+			t.Fatalf("expected the same complete resource types for the concurrent callers")
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/Azure/aztfmigrate/azurerm"
@@ -77,7 +76,7 @@ func (c AztfMigrateCommand) Handle(ctx context.Context, arguments []json.RawMess
 	}
 
 	// creating temp workspace
-	workingDirectory := getWorkingDirectory(string(params.TextDocument.URI), runtime.GOOS)
+	workingDirectory := workingDirectory(ctx, params.TextDocument.URI)
 	tempDir := filepath.Join(workingDirectory, tempFolderName)
 	if err := os.MkdirAll(tempDir, 0750); err != nil {
 		return nil, fmt.Errorf("failed to create temp workspace %q, please check the permission: %w", tempDir, err)
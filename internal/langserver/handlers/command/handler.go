@@ -3,8 +3,36 @@ package command
 import (
 	"context"
 	"encoding/json"
+	"runtime"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
 )
 
 type CommandHandler interface {
 	Handle(ctx context.Context, params []json.RawMessage) (interface{}, error)
 }
+
+// clientSettings returns the settings configured by the client for the path, or the default settings if they aren't
+// in the context. The settings of the workspace folder which contains the path override the workspace settings.
+func clientSettings(ctx context.Context, path string) settings.Settings {
+	s, err := lsctx.Settings(ctx)
+	if err != nil {
+		return settings.DefaultSettings()
+	}
+	if folders, err := lsctx.WorkspaceFolders(ctx); err == nil {
+		return folders.Settings(s, path)
+	}
+	return s
+}
+
+// workingDirectory returns the Terraform working directory of the document, it's the directory in the workspace
+// folder which is initialized by terraform init, or the directory of the document
+func workingDirectory(ctx context.Context, uri lsp.DocumentURI) string {
+	dir := getWorkingDirectory(string(uri), runtime.GOOS)
+	if folders, err := lsctx.WorkspaceFolders(ctx); err == nil {
+		return folders.WorkingDirectory(dir)
+	}
+	return dir
+}
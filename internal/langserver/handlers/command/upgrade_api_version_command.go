@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type UpgradeApiVersionCommand struct {
+}
+
+var _ CommandHandler = &UpgradeApiVersionCommand{}
+
+// UpgradeApiVersionParams is the argument of the command, the address is like azapi_resource.example. The `type` of
+// the resource is looked up when the command runs, because the document may be changed after the code lens is shown.
+type UpgradeApiVersionParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+	Address      string                     `json:"address"`
+	ResourceType string                     `json:"resourceType"`
+	ApiVersion   string                     `json:"apiVersion"`
+}
+
+func (c UpgradeApiVersionCommand) Handle(ctx context.Context, arguments []json.RawMessage) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, nil
+	}
+	var params UpgradeApiVersionParams
+	err := json.Unmarshal(arguments[0], &params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+	}
+	if params.Address == "" || params.ResourceType == "" || params.ApiVersion == "" {
+		return nil, fmt.Errorf("address, resource type and api-version are required")
+	}
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+	file, err := doc.ParsedFile()
+	if err != nil {
+		return nil, err
+	}
+	typeRange, err := resourceTypeRange(file, params.Address, params.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	telemetrySender, err := lsctx.Telemetry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCaller, err := lsctx.ClientCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	telemetrySender.SendEvent(ctx, "upgradeApiVersion", map[string]interface{}{
+		"type": fmt.Sprintf("%s@%s", params.ResourceType, params.ApiVersion),
+	})
+
+	_, err = clientCaller.Callback(ctx, "workspace/applyEdit", lsp.ApplyWorkspaceEditParams{
+		Label: "Upgrade API version",
+		Edit: lsp.WorkspaceEdit{
+			Changes: map[string][]lsp.TextEdit{
+				string(params.TextDocument.URI): {
+					{
+						Range:   ilsp.HCLRangeToLSP(typeRange),
+						NewText: fmt.Sprintf("%s@%s", params.ResourceType, params.ApiVersion),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("applying edit: %w", err)
+	}
+
+	return nil, nil
+}
+
+// resourceTypeRange returns the range of the `type` value without quotes of the resource at the address, the resource
+// type in the value must be the given one
+func resourceTypeRange(file *parser.File, address string, resourceType string) (hcl.Range, error) {
+	if file.Body == nil {
+		return hcl.Range{}, fmt.Errorf("resource %s is not found", address)
+	}
+	for _, block := range file.Body.Blocks {
+		if block.Type != "resource" || strings.Join(block.Labels, ".") != address {
+			continue
+		}
+		typeAttribute := parser.AttributeWithName(block, "type")
+		typeValue := parser.ExtractAzureResourceType(block)
+		if typeAttribute == nil || typeValue == nil {
+			return hcl.Range{}, fmt.Errorf("the type of resource %s is not a literal value", address)
+		}
+		if parts := strings.Split(*typeValue, "@"); !strings.EqualFold(parts[0], resourceType) {
+			return hcl.Range{}, fmt.Errorf("the resource type of %s is changed to %s", address, parts[0])
+		}
+		typeRange := typeAttribute.Expr.Range()
+		if templateExpr, ok := typeAttribute.Expr.(*hclsyntax.TemplateExpr); ok && templateExpr.IsStringLiteral() {
+			typeRange = templateExpr.Parts[0].Range()
+		}
+		return typeRange, nil
+	}
+	return hcl.Range{}, fmt.Errorf("resource %s is not found", address)
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+)
+
+func Test_resourceTypeRange(t *testing.T) {
+	src := []byte(`# the lines above the resource may be changed after the code lens is shown
+resource "azapi_resource" "rg" {
+  type = "Microsoft.Resources/resourceGroups@2020-06-01"
+  name = "example"
+}
+`)
+	file := parser.ParseFile(src, "main.tf")
+
+	r, err := resourceTypeRange(file, "azapi_resource.rg", "Microsoft.Resources/resourceGroups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := string(src[r.Start.Byte:r.End.Byte]); actual != "Microsoft.Resources/resourceGroups@2020-06-01" {
+		t.Errorf("expect the range of the type value, but got %q", actual)
+	}
+
+	if _, err := resourceTypeRange(file, "azapi_resource.rg", "Microsoft.Storage/storageAccounts"); err == nil {
+		t.Error("expect an error when the resource type is changed")
+	}
+	if _, err := resourceTypeRange(file, "azapi_resource.removed", "Microsoft.Resources/resourceGroups"); err == nil {
+		t.Error("expect an error when the resource is not found")
+	}
+}
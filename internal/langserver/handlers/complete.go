@@ -46,12 +46,12 @@ func (svc *service) TextDocumentComplete(ctx context.Context, params lsp.Complet
 
 	svc.logger.Printf("Looking for candidates at %q -> %#v", doc.Filename(), fPos.Position())
 
-	data, err := doc.Text()
+	file, err := doc.ParsedFile()
 	if err != nil {
 		return list, err
 	}
 
-	candidates := CandidatesAtPos(data, doc.Filename(), fPos.Position(), svc.logger)
+	candidates := CandidatesAtPos(file, fPos.Position(), svc.logger)
 	sort.Slice(candidates, func(i, j int) bool { return candidates[i].SortText < candidates[j].SortText })
 
 	return lsp.CompletionList{
@@ -60,11 +60,9 @@ func (svc *service) TextDocumentComplete(ctx context.Context, params lsp.Complet
 	}, nil
 }
 
-func CandidatesAtPos(data []byte, filename string, pos hcl.Pos, logger *log.Logger) []lsp.CompletionItem {
-	file, _ := hclsyntax.ParseConfig(data, filename, hcl.InitialPos)
-
-	body, isHcl := file.Body.(*hclsyntax.Body)
-	if !isHcl {
+func CandidatesAtPos(file *parser.File, pos hcl.Pos, logger *log.Logger) []lsp.CompletionItem {
+	body := file.Body
+	if body == nil {
 		logger.Printf("file is not hcl")
 		return nil
 	}
@@ -94,7 +92,7 @@ func CandidatesAtPos(data []byte, filename string, pos hcl.Pos, logger *log.Logg
 		candidateList = append(candidateList, snippets.AzAPITemplateCandidates(editRange)...)
 
 		// azurerm templates
-		if shouldGiveTopLevelCompletions(string(data), pos.Line-1) {
+		if shouldGiveTopLevelCompletions(string(file.Src), pos.Line-1) {
 			candidateList = append(candidateList, snippets.AzureRMTemplateCandidates(editRange)...)
 		}
 		return candidateList
@@ -119,7 +117,7 @@ func CandidatesAtPos(data []byte, filename string, pos hcl.Pos, logger *log.Logg
 			return candidateList
 		}
 		if property.GenericCandidatesFunc != nil {
-			candidateList = append(candidateList, property.GenericCandidatesFunc(data, filename, resourceBlock, attribute, pos, property)...)
+			candidateList = append(candidateList, property.GenericCandidatesFunc(file, resourceBlock, attribute, pos, property)...)
 		} else if property.ValueCandidatesFunc != nil {
 			prefix := parser.ToLiteral(attribute.Expr)
 			candidateList = append(candidateList, property.ValueCandidatesFunc(prefix, editRangeFromExprRange(attribute.Expr, pos))...)
@@ -140,7 +138,7 @@ func CandidatesAtPos(data []byte, filename string, pos hcl.Pos, logger *log.Logg
 		}
 
 		if blockPath == "" {
-			candidateList = append(candidateList, msgraph.MSGraphCodeSampleCandidates(resourceBlock, *editRange, data)...)
+			candidateList = append(candidateList, msgraph.MSGraphCodeSampleCandidates(resourceBlock, *editRange, file.Src)...)
 			candidateList = append(candidateList, azapi.CodeSampleCandidates(resourceBlock, *editRange)...)
 		}
 
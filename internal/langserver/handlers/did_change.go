@@ -3,11 +3,19 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"log"
 
 	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	"github.com/Azure/ms-terraform-lsp/internal/filesystem"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/command"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/validate"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
+	"github.com/Azure/ms-terraform-lsp/internal/workspace"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 func TextDocumentDidChange(ctx context.Context, params lsp.DidChangeTextDocumentParams) error {
@@ -49,18 +57,57 @@ func TextDocumentDidChange(ctx context.Context, params lsp.DidChangeTextDocument
 		return err
 	}
 
+	return publishDiagnostics(ctx, f)
+}
+
+// publishDiagnostics updates the document in the module index of its workspace folder, then validates it with the
+// settings of the folder and publishes the diagnostics
+func publishDiagnostics(ctx context.Context, doc filesystem.Document) error {
 	notifier, err := lsctx.DiagnosticsNotifier(ctx)
 	if err != nil {
 		return err
 	}
 
-	src, err := f.Text()
+	settings, err := lsctx.Settings(ctx)
+	if err != nil {
+		return err
+	}
+
+	folders, err := lsctx.WorkspaceFolders(ctx)
+	if err != nil {
+		return err
+	}
+	settings = folders.Settings(settings, doc.FullPath())
+	index := folders.Index(doc.Dir())
+
+	file, err := doc.ParsedFile()
 	if err != nil {
 		return err
 	}
 
-	diags := validate.NewDiagnostics(src, f.Filename())
-	notifier.PublishHCLDiags(ctx, f.Dir(), diags)
+	index.SetParsedFile(doc.FullPath(), file)
+	mod, err := index.Module(doc.Dir())
+	if err != nil {
+		log.Printf("failed to load module %q: %+v", doc.Dir(), err)
+	}
 
+	diags := validate.NewDiagnostics(file, mod, parentTypeResolver(mod, azurermResourceTypeFunc(folders, settings, doc.Dir())), settings.ValidationOptions())
+	notifier.PublishHCLDiags(ctx, doc.Dir(), diags)
 	return nil
 }
+
+// parentTypeResolver returns the resolver which infers the parent resource type from the parent_id expression
+func parentTypeResolver(mod *module.Module, azurermResourceType func(key string) string) validate.ParentTypeResolver {
+	return func(expr hclsyntax.Expression) string {
+		return tfschema.ParentResourceType(expr, mod, azurermResourceType)
+	}
+}
+
+// azurermResourceTypeFunc returns the function which looks up the ARM resource type of the azurerm resources in the
+// directory. It doesn't wait for the azurerm mapping, the types are empty until the mapping of the Terraform working
+// directory is loaded in the background.
+func azurermResourceTypeFunc(folders *workspace.Folders, s settings.Settings, dir string) func(key string) string {
+	return func(key string) string {
+		return command.AzurermResourceTypes(folders.WorkingDirectory(dir), s)[key]
+	}
+}
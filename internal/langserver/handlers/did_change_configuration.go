@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
+	"github.com/Azure/ms-terraform-lsp/internal/telemetry"
+)
+
+// WorkspaceDidChangeConfiguration replaces the settings, the telemetry is turned on or off accordingly,
+// and the open documents are validated again, so the changes apply without restarting the server
+func (svc *service) WorkspaceDidChangeConfiguration(ctx context.Context, params lsp.DidChangeConfigurationParams) error {
+	// the clients which pull the settings send null, the current settings are kept instead of the default settings
+	if params.Settings == nil {
+		return nil
+	}
+
+	s, err := settings.DecodeSettings(params.Settings)
+	if err != nil {
+		return err
+	}
+	svc.setSettings(s)
+
+	switch {
+	case !s.Telemetry.Enabled:
+		svc.setTelemetry(&telemetry.NoopSender{Logger: svc.logger})
+	case svc.telemetryVersion != 0:
+		if _, isNoop := svc.currentTelemetry().(*telemetry.NoopSender); isNoop {
+			if err := svc.setupTelemetry(svc.telemetryVersion, svc.server); err != nil {
+				svc.logger.Printf("failed to setup telemetry: %s", err)
+			}
+		}
+	}
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = lsctx.WithSettings(ctx, s)
+	for _, doc := range fs.OpenDocuments() {
+		if err := publishDiagnostics(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
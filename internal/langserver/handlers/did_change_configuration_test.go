@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+)
+
+func TestDidChangeConfiguration_disableValidation(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile("./testdata/TestCodeAction_quickFix/main.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "workspace/didChangeConfiguration",
+		ReqParams: `{"settings": {"validation": {"azapi": false}}}`,
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/codeAction",
+		ReqParams: buildReqParamsCodeAction(6, 7, 6, 7, tmpDir.URI()),
+	}, `{"jsonrpc":"2.0","id":3,"result":null}`)
+}
+
+func TestDidChangeConfiguration_nullSettings(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile("./testdata/TestCodeAction_quickFix/main.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345,
+		"initializationOptions": {"validation": {"azapi": false}}
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "workspace/didChangeConfiguration",
+		ReqParams: `{"settings": null}`,
+	})
+
+	// the settings from the initializationOptions are kept
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/codeAction",
+		ReqParams: buildReqParamsCodeAction(6, 7, 6, 7, tmpDir.URI()),
+	}, `{"jsonrpc":"2.0","id":3,"result":null}`)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+)
+
+// WorkspaceDidChangeWatchedFiles updates the module indexes with the files which are changed outside the client,
+// the open documents are skipped, because their content in the client is newer than the one on the disk
+func WorkspaceDidChangeWatchedFiles(ctx context.Context, params lsp.DidChangeWatchedFilesParams) error {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	folders, err := lsctx.WorkspaceFolders(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range params.Changes {
+		fh := ilsp.FileHandlerFromDocumentURI(change.URI)
+		// the documents in the storage are the ones open in the client
+		if _, err := fs.GetDocument(fh); err == nil {
+			continue
+		}
+		index := folders.Index(fh.FullPath())
+		switch change.Type {
+		case lsp.Created, lsp.Changed:
+			if err := index.ReloadFile(fh.FullPath()); err != nil {
+				log.Printf("failed to reload file %q: %+v", fh.FullPath(), err)
+			}
+		case lsp.Deleted:
+			index.RemoveFile(fh.FullPath())
+		}
+	}
+	return nil
+}
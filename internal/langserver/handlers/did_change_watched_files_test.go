@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+)
+
+func TestDidChangeWatchedFiles_deleted(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+	copyTestdataModule(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	reqParams := fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 0,
+				"character": 28
+			},
+			"context": {
+				"includeDeclaration": false
+			}
+		}`, tmpDir.URI())
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/references",
+		ReqParams: reqParams,
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"uri": "%[1]s/main.tf",
+				"range": {
+					"start": {"line": 7, "character": 10},
+					"end": {"line": 7, "character": 27}
+				}
+			},
+			{
+				"uri": "%[1]s/vnet.tf",
+				"range": {
+					"start": {"line": 2, "character": 14},
+					"end": {"line": 2, "character": 31}
+				}
+			},
+			{
+				"uri": "%[1]s/vnet.tf",
+				"range": {
+					"start": {"line": 4, "character": 14},
+					"end": {"line": 4, "character": 31}
+				}
+			}
+		]
+	}`, tmpDir.URI()))
+
+	if err := os.Remove(filepath.Join(tmpDir.Dir(), "vnet.tf")); err != nil {
+		t.Fatal(err)
+	}
+	ls.Notify(t, &langserver.CallRequest{
+		Method: "workspace/didChangeWatchedFiles",
+		ReqParams: fmt.Sprintf(`{
+			"changes": [
+				{"uri": "%s/vnet.tf", "type": 3}
+			]
+		}`, tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/references",
+		ReqParams: reqParams,
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": [
+			{
+				"uri": "%[1]s/main.tf",
+				"range": {
+					"start": {"line": 7, "character": 10},
+					"end": {"line": 7, "character": 27}
+				}
+			}
+		]
+	}`, tmpDir.URI()))
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/workspace"
+)
+
+// workspaceFoldersRegistrationID is the id under which the client registers the workspace folder change notifications
+const workspaceFoldersRegistrationID = "ms-terraform-lsp-workspace-folders"
+
+// WorkspaceDidChangeWorkspaceFolders tracks the folders which are added to or removed from the workspace, the added
+// folders are indexed in the background and the indexes of the removed folders are dropped
+func (svc *service) WorkspaceDidChangeWorkspaceFolders(ctx context.Context, params lsp.DidChangeWorkspaceFoldersParams) error {
+	for _, folder := range params.Event.Removed {
+		dir := ilsp.FileHandlerFromDirURI(lsp.DocumentURI(folder.URI)).Dir()
+		if svc.folders.Remove(dir) == nil {
+			svc.logger.Printf("workspace folder %q isn't tracked", dir)
+			continue
+		}
+		// the modules may be indexed by the parent folder before the changes in the removed folder, they're loaded again
+		svc.folders.Index(dir).RemoveFile(dir)
+	}
+
+	added := make([]*workspace.Folder, 0)
+	for _, folder := range params.Event.Added {
+		dir := ilsp.FileHandlerFromDirURI(lsp.DocumentURI(folder.URI)).Dir()
+		if f, ok := svc.folders.Add(folder.Name, dir); ok {
+			added = append(added, f)
+		}
+	}
+	go svc.indexFolders(added)
+	return nil
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+)
+
+func TestDidChangeWorkspaceFolders_symbols(t *testing.T) {
+	tmpDir := TempDir(t, "platform", "app")
+	for _, folder := range []string{"platform", "app"} {
+		config := fmt.Sprintf(`resource "azapi_resource" "%s" {
+  type = "Microsoft.Resources/resourceGroups@2021-04-01"
+}
+`, folder)
+		if err := os.WriteFile(filepath.Join(tmpDir.Dir(), folder, "main.tf"), []byte(config), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {"workspace": {"workspaceFolders": true}},
+		"processId": 12345,
+		"initializationOptions": {"offline": true},
+		"workspaceFolders": [{"uri": "%s/platform", "name": "platform"}]
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method: "workspace/didChangeWorkspaceFolders",
+		ReqParams: fmt.Sprintf(`{
+		"event": {
+			"added": [{"uri": "%[1]s/app", "name": "app"}],
+			"removed": [{"uri": "%[1]s/platform", "name": "platform"}]
+		}
+	}`, tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResultEventually(t, &langserver.CallRequest{
+		Method:    "workspace/symbol",
+		ReqParams: `{"query": "azapi_resource"}`,
+	}, fmt.Sprintf(`[
+		{
+			"name": "Microsoft.Resources/resourceGroups@2021-04-01",
+			"kind": 5,
+			"location": {
+				"uri": "%s/app/main.tf",
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 2, "character": 1}
+				}
+			},
+			"containerName": "azapi_resource.app"
+		}
+	]`, tmpDir.URI()), 5*time.Second)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"log"
 
 	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
@@ -20,5 +21,14 @@ func TextDocumentDidClose(ctx context.Context, params lsp.DidCloseTextDocumentPa
 		return err
 	}
 
+	// the unsaved changes are discarded when the document is closed, the file on the disk is indexed again
+	folders, err := lsctx.WorkspaceFolders(ctx)
+	if err != nil {
+		return err
+	}
+	if err := folders.Index(fh.Dir()).ReloadFile(fh.FullPath()); err != nil {
+		log.Printf("failed to reload file %q: %+v", fh.FullPath(), err)
+	}
+
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
-	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/validate"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
 )
@@ -21,12 +20,10 @@ func (lh *logHandler) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpe
 		return err
 	}
 
-	notifier, err := lsctx.DiagnosticsNotifier(ctx)
+	doc, err := fs.GetDocument(f)
 	if err != nil {
 		return err
 	}
 
-	diags := validate.NewDiagnostics(f.Text(), f.Filename())
-	notifier.PublishHCLDiags(ctx, f.Dir(), diags)
-	return nil
+	return publishDiagnostics(ctx, doc)
 }
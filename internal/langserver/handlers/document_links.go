@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl-lang/lang"
+)
+
+func (svc *service) TextDocumentLink(ctx context.Context, params lsp.DocumentLinkParams) ([]lsp.DocumentLink, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := doc.ParsedFile()
+	if err != nil {
+		return nil, err
+	}
+
+	body := file.Body
+	if body == nil {
+		return []lsp.DocumentLink{}, nil
+	}
+
+	links := make([]lang.Link, 0)
+	for _, block := range body.Blocks {
+		links = append(links, tfschema.DocumentLinks(block, file.Src)...)
+	}
+
+	return ilsp.Links(links, cc.TextDocument.DocumentLink), nil
+}
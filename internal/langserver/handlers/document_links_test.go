@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
+)
+
+func TestDocumentLink_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/documentLink",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, TempDir(t).URI()),
+	}, session.SessionNotInitialized.Err())
+}
+
+func TestDocumentLink_basic(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"textDocument": {
+				"documentLink": {
+					"tooltipSupport": true
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/documentLink",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI()),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 1, "character": 15},
+					"end": {"line": 1, "character": 59}
+				},
+				"target": "https://learn.microsoft.com/en-us/azure/templates/microsoft.network/2024-01-01/virtualnetworks?pivots=deployment-language-terraform",
+				"tooltip": "View the template reference of Microsoft.Network/virtualNetworks@2024-01-01"
+			},
+			{
+				"range": {
+					"start": {"line": 7, "character": 9},
+					"end": {"line": 7, "character": 21}
+				},
+				"target": "https://learn.microsoft.com/graph/api/application-post-applications?view=graph-rest-1.0",
+				"tooltip": "View the documentation of applications@v1.0"
+			}
+		]
+	}`)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+)
+
+func (svc *service) TextDocumentSymbol(ctx context.Context, params lsp.DocumentSymbolParams) ([]lsp.DocumentSymbol, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	return ilsp.DocumentSymbols(mod.FileSymbols(doc.Filename()), cc.TextDocument.DocumentSymbol), nil
+}
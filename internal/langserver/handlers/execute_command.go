@@ -15,10 +15,11 @@ const (
 	CommandAztfAuthorize      = "ms-terraform.aztfauthorize"
 	CommandConvertJsonToAzapi = "ms-terraform.convertJsonToAzapi"
 	CommandAztfMigrate        = "ms-terraform.aztfmigrate"
+	CommandUpgradeApiVersion  = "ms-terraform.upgradeApiVersion"
 )
 
 func availableCommands() []string {
-	return []string{CommandTelemetry, CommandAztfAuthorize, CommandConvertJsonToAzapi, CommandAztfMigrate}
+	return []string{CommandTelemetry, CommandAztfAuthorize, CommandConvertJsonToAzapi, CommandAztfMigrate, CommandUpgradeApiVersion}
 }
 
 func init() {
@@ -27,6 +28,7 @@ func init() {
 	handlerMap[CommandAztfAuthorize] = command.AztfAuthorizeCommand{}
 	handlerMap[CommandConvertJsonToAzapi] = command.ConvertJsonCommand{}
 	handlerMap[CommandAztfMigrate] = command.AztfMigrateCommand{}
+	handlerMap[CommandUpgradeApiVersion] = command.UpgradeApiVersionCommand{}
 }
 
 func (svc *service) WorkspaceExecuteCommand(ctx context.Context, params lsp.ExecuteCommandParams) (interface{}, error) {
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+)
+
+func (svc *service) GoToDefinition(ctx context.Context, params lsp.TextDocumentPositionParams) (interface{}, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.goToReferenceTarget(ctx, params, cc.TextDocument.Definition.LinkSupport)
+}
+
+func (svc *service) GoToDeclaration(ctx context.Context, params lsp.TextDocumentPositionParams) (interface{}, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.goToReferenceTarget(ctx, params, cc.TextDocument.Declaration.LinkSupport)
+}
+
+func (svc *service) goToReferenceTarget(ctx context.Context, params lsp.TextDocumentPositionParams, linkSupport bool) (interface{}, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.Printf("Looking for reference targets at %q -> %#v", doc.Filename(), fPos.Position())
+	targets := mod.ReferenceTargetsForOriginAtPos(doc.Filename(), fPos.Position())
+
+	return ilsp.RefTargetsToLocationLinks(targets, linkSupport), nil
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
+)
+
+func TestDefinition_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method:    "textDocument/definition",
+		ReqParams: buildReqParamsHover(1, 1, TempDir(t).URI()),
+	}, session.SessionNotInitialized.Err())
+}
+
+func TestDefinition_crossFile(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+	copyTestdataModule(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"textDocument": {
+				"definition": {
+					"linkSupport": true
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/definition",
+		ReqParams: buildReqParamsHover(3, 20, tmpDir.URI()),
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"originSelectionRange": {
+					"start": {"line": 2, "character": 14},
+					"end": {"line": 2, "character": 31}
+				},
+				"targetUri": "%s/resource_group.tf",
+				"targetRange": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 4, "character": 1}
+				},
+				"targetSelectionRange": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 30}
+				}
+			}
+		]
+	}`, tmpDir.URI()))
+
+	// the cursor is not on a reference
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/definition",
+		ReqParams: buildReqParamsHover(2, 5, tmpDir.URI()),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": []
+	}`)
+}
+
+func TestDeclaration_crossFile(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+	copyTestdataModule(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/declaration",
+		ReqParams: buildReqParamsHover(5, 20, tmpDir.URI()),
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"uri": "%s/resource_group.tf",
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 4, "character": 1}
+				}
+			}
+		]
+	}`, tmpDir.URI()))
+}
+
+// copyTestdataModule copies the .tf files of the test, except main.tf which is opened by the test, to the module directory
+func copyTestdataModule(t *testing.T, dir string) {
+	files, err := filepath.Glob(fmt.Sprintf("./testdata/%s/*.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if filepath.Base(file) == "main.tf" {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dir, filepath.Base(file)), content, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
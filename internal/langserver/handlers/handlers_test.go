@@ -35,21 +35,33 @@ func initializeResponse(t *testing.T, commandPrefix string) string {
 			  "completionItem": {}
 			},
 			"hoverProvider": true,
-			"declarationProvider": false,
+			"declarationProvider": true,
+			"definitionProvider": true,
+			"referencesProvider": true,
+			"documentHighlightProvider": true,
+			"documentSymbolProvider": true,
 			"codeActionProvider": {
 			  "codeActionKinds": [
-				"refactor.rewrite"
+				"quickfix",
+				"refactor.rewrite",
+				"source.fixAll.azure"
 			  ]
 			},
+			"codeLensProvider": {},
+			"documentLinkProvider": {},
+			"workspaceSymbolProvider": true,
+			"renameProvider": true,
 			"executeCommandProvider": {
 				"commands": [
 					"ms-terraform.telemetry",
 					"ms-terraform.aztfauthorize",
 					"ms-terraform.convertJsonToAzapi",
-					"ms-terraform.aztfmigrate"
+					"ms-terraform.aztfmigrate",
+					"ms-terraform.upgradeApiVersion"
 				],
 				"workDoneProgress": true
-			}
+			},
+			"inlayHintProvider": {}
 		  },
 		  "serverInfo": {
 			"name": "azurerm-lsp"
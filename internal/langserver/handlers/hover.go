@@ -37,7 +37,7 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 		return nil, err
 	}
 
-	data, err := doc.Text()
+	file, err := doc.ParsedFile()
 	if err != nil {
 		return nil, err
 	}
@@ -48,16 +48,15 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 	}
 
 	svc.logger.Printf("Looking for hover data at %q -> %#v", doc.Filename(), fPos.Position())
-	hoverData := HoverAtPos(ctx, data, doc.Filename(), fPos.Position(), svc.logger, telemetrySender)
+	hoverData := HoverAtPos(ctx, file, fPos.Position(), svc.logger, telemetrySender)
 	svc.logger.Printf("received hover data: %#v", hoverData)
 
 	return hoverData, nil
 }
 
-func HoverAtPos(ctx context.Context, data []byte, filename string, pos hcl.Pos, logger *log.Logger, sender telemetry.Sender) *lsp.Hover {
-	file, _ := hclsyntax.ParseConfig(data, filename, hcl.InitialPos)
-	body, isHcl := file.Body.(*hclsyntax.Body)
-	if !isHcl {
+func HoverAtPos(ctx context.Context, file *parser.File, pos hcl.Pos, logger *log.Logger, sender telemetry.Sender) *lsp.Hover {
+	body := file.Body
+	if body == nil {
 		logger.Printf("file is not hcl")
 		return nil
 	}
@@ -94,7 +93,7 @@ func HoverAtPos(ctx context.Context, data []byte, filename string, pos hcl.Pos,
 			return nil
 		}
 		if property.CustomizedHoverFunc != nil {
-			return property.CustomizedHoverFunc(resourceBlock, attribute, pos, data)
+			return property.CustomizedHoverFunc(resourceBlock, attribute, pos, file)
 		}
 		if !parser.ContainsPos(attribute.NameRange, pos) {
 			return nil
@@ -129,7 +128,7 @@ func HoverAtPos(ctx context.Context, data []byte, filename string, pos hcl.Pos,
 			docId = fmt.Sprintf("azapi_resource.%s", typeValue)
 
 		case strings.Contains(resourceName, "msgraph_resource"):
-			url := parser.ExtractMSGraphUrl(resourceBlock, data)
+			url := parser.ExtractMSGraphUrl(resourceBlock, file.Src)
 			apiVersion := "v1.0"
 			if v := parser.BlockAttributeLiteralValue(resourceBlock, "api_version"); v != nil {
 				apiVersion = *v
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 
 	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
 	"github.com/creachadair/jrpc2"
 )
 
@@ -34,14 +37,18 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 				CodeActionKinds: ilsp.SupportedCodeActions.AsSlice(),
 				ResolveProvider: false,
 			},
-			DeclarationProvider:        false,
-			DefinitionProvider:         false,
-			CodeLensProvider:           nil,
-			ReferencesProvider:         false,
+			DeclarationProvider:        true,
+			DefinitionProvider:         true,
+			CodeLensProvider:           &lsp.CodeLensOptions{},
+			DocumentLinkProvider:       &lsp.DocumentLinkOptions{},
+			ReferencesProvider:         true,
+			DocumentHighlightProvider:  true,
+			RenameProvider:             true,
 			DocumentFormattingProvider: false,
-			DocumentSymbolProvider:     false,
-			WorkspaceSymbolProvider:    false,
+			DocumentSymbolProvider:     true,
+			WorkspaceSymbolProvider:    true,
 			Workspace:                  nil,
+			InlayHintProvider:          &lsp.InlayHintOptions{},
 
 			ExecuteCommandProvider: &lsp.ExecuteCommandOptions{
 				Commands: availableCommands(),
@@ -68,7 +75,20 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 		return serverCaps, err
 	}
 
+	s, err := settings.DecodeSettings(params.InitializationOptions)
+	if err != nil {
+		svc.logger.Printf("failed to decode initialization options: %s", err)
+		_ = jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+			Type:    lsp.Warning,
+			Message: fmt.Sprintf("The default settings are used: %s", err),
+		})
+	}
+	svc.setSettings(s)
+
 	if tv, ok := expClientCaps.TelemetryVersion(); ok {
+		svc.telemetryVersion = tv
+	}
+	if tv := svc.telemetryVersion; tv != 0 && s.Telemetry.Enabled {
 		svc.logger.Printf("enabling telemetry (version: %d)", tv)
 		err := svc.setupTelemetry(tv, svc.server)
 		if err != nil {
@@ -84,11 +104,47 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 		}
 	}
 
+	if clientCaps.TextDocument.Rename.PrepareSupport {
+		serverCaps.Capabilities.RenameProvider = lsp.RenameOptions{
+			PrepareProvider: true,
+		}
+	}
+
+	stCaps := ilsp.SemanticTokensClientCapabilities{
+		SemanticTokensClientCapabilities: clientCaps.TextDocument.SemanticTokens,
+	}
+	if stCaps.FullRequest() {
+		serverCaps.Capabilities.SemanticTokensProvider = lsp.SemanticTokensOptions{
+			Legend: lsp.SemanticTokensLegend{
+				TokenTypes:     ilsp.TokenTypesLegend(stCaps.TokenTypes).AsStrings(),
+				TokenModifiers: ilsp.TokenModifiersLegend(stCaps.TokenModifiers).AsStrings(),
+			},
+			Full: true,
+		}
+	}
+
 	err = ilsp.SetClientCapabilities(ctx, &clientCaps)
 	if err != nil {
 		return serverCaps, err
 	}
 
+	for _, folder := range params.WorkspaceFolders {
+		svc.folders.Add(folder.Name, ilsp.FileHandlerFromDirURI(lsp.DocumentURI(folder.URI)).Dir())
+	}
+	if len(params.WorkspaceFolders) == 0 && params.RootURI != "" {
+		dir := ilsp.FileHandlerFromDirURI(params.RootURI).Dir()
+		svc.folders.Add(filepath.Base(dir), dir)
+	}
+
+	if clientCaps.Workspace.WorkspaceFolders {
+		serverCaps.Capabilities.Workspace = &lsp.Workspace5Gn{
+			WorkspaceFolders: lsp.WorkspaceFolders4Gn{
+				Supported:           true,
+				ChangeNotifications: workspaceFoldersRegistrationID,
+			},
+		}
+	}
+
 	if !clientCaps.Workspace.WorkspaceFolders && len(params.WorkspaceFolders) > 0 {
 		_ = jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
 			Type: lsp.Warning,
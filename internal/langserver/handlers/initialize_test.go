@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -128,3 +129,40 @@ func TestName(t *testing.T) {
 	data, _ := json.Marshal(serverCaps)
 	fmt.Println(string(data))
 }
+
+func TestInitialize_severityOff(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile("./testdata/TestCodeAction_quickFix/main.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345,
+		"initializationOptions": {"diagnostics": {"severity": {"unknown-property": "off"}}}
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/codeAction",
+		ReqParams: buildReqParamsCodeAction(6, 7, 6, 7, tmpDir.URI()),
+	}, `{"jsonrpc":"2.0","id":3,"result":null}`)
+}
@@ -3,9 +3,58 @@ package handlers
 import (
 	"context"
 
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/command"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/workspace"
 )
 
-func Initialized(ctx context.Context, params lsp.InitializedParams) error {
+// watchedFilesRegistrationID is the id of the registration which watches the terraform configuration files
+const watchedFilesRegistrationID = "ms-terraform-lsp-watched-files"
+
+// Initialized indexes the modules in the workspace folders in the background, and asks the client to notify the
+// changes of the .tf files, so the index is kept current even if the files aren't opened
+func (svc *service) Initialized(ctx context.Context, params lsp.InitializedParams) error {
+	go svc.indexFolders(svc.folders.List())
+
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if cc.Workspace.DidChangeWatchedFiles.DynamicRegistration && svc.clientCaller != nil {
+		// the client may send requests while handling this one, so the registration doesn't block the notification
+		go func() {
+			_, err := svc.clientCaller.Callback(svc.sessCtx, "client/registerCapability", lsp.RegistrationParams{
+				Registrations: []lsp.Registration{
+					{
+						ID:     watchedFilesRegistrationID,
+						Method: "workspace/didChangeWatchedFiles",
+						RegisterOptions: lsp.DidChangeWatchedFilesRegistrationOptions{
+							Watchers: []lsp.FileSystemWatcher{
+								{GlobPattern: "**/*.tf"},
+								{GlobPattern: "**/", Kind: uint32(lsp.WatchDelete)},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				svc.logger.Printf("failed to register watched files: %s", err)
+			}
+		}()
+	}
 	return nil
 }
+
+// indexFolders indexes the modules in the workspace folders, and starts loading the azurerm mapping of the folders,
+// so it's ready when the diagnostics and the inlay hints look up the azurerm resource types
+func (svc *service) indexFolders(folders []*workspace.Folder) {
+	for _, folder := range folders {
+		command.LoadAzurermMapping(svc.folders.WorkingDirectory(folder.Path), svc.folders.Settings(svc.currentSettings(), folder.Path))
+		if err := folder.Index.IndexFolder(folder.Path); err != nil {
+			svc.logger.Printf("failed to index workspace folder %q: %s", folder.Path, err)
+			continue
+		}
+		svc.logger.Printf("indexed %d modules in workspace folder %q", len(folder.Index.Modules(folder.Path)), folder.Path)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func (svc *service) TextDocumentInlayHint(ctx context.Context, params lsp.InlayHintParams) ([]lsp.InlayHint, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := doc.ParsedFile()
+	if err != nil {
+		return nil, err
+	}
+
+	body := file.Body
+	if body == nil {
+		return []lsp.InlayHint{}, nil
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	// the azurerm resource types are looked up only when a parent_id refers to an azurerm resource
+	azurermResourceType := azurermResourceTypeFunc(svc.folders, svc.folders.Settings(svc.currentSettings(), doc.FullPath()), doc.Dir())
+
+	hints := make([]lsp.InlayHint, 0)
+	for _, block := range body.Blocks {
+		if !rangeOverlaps(ilsp.HCLRangeToLSP(block.Range()), params.Range) {
+			continue
+		}
+		if hint := parentIdInlayHint(block, mod, azurermResourceType); hint != nil {
+			hints = append(hints, *hint)
+		}
+		hints = append(hints, tfschema.BodyInlayHints(block, file.Src)...)
+	}
+
+	res := make([]lsp.InlayHint, 0)
+	for _, hint := range hints {
+		if positionInRange(hint.Position, params.Range) {
+			res = append(res, hint)
+		}
+	}
+	return res, nil
+}
+
+// parentIdInlayHint returns the inlay hint which shows the scope that the parent_id refers to,
+// when the scope can't be resolved, the hint shows the scopes which the resource type can be deployed to
+func parentIdInlayHint(block *hclsyntax.Block, mod *module.Module, azurermResourceType func(key string) string) *lsp.InlayHint {
+	if len(block.Labels) == 0 || !strings.HasPrefix(block.Labels[0], "azapi_") || block.Labels[0] == "azapi_data_plane_resource" {
+		return nil
+	}
+	attribute := parser.AttributeWithName(block, "parent_id")
+	if attribute == nil {
+		return nil
+	}
+
+	hint := &lsp.InlayHint{
+		Position:    ilsp.HCLPosToLSP(attribute.Expr.Range().End),
+		Kind:        lsp.InlayHintKindType,
+		PaddingLeft: true,
+	}
+	if parentType := tfschema.ParentResourceType(attribute.Expr, mod, azurermResourceType); parentType != "" {
+		hint.Label = tfschema.ScopeLabel(parentType)
+		hint.Tooltip = fmt.Sprintf("The parent_id refers to %s", parentType)
+		return hint
+	}
+
+	typeValue := parser.ExtractAzureResourceType(block)
+	if typeValue == nil {
+		return nil
+	}
+	parts := strings.Split(*typeValue, "@")
+	if len(parts) != 2 {
+		return nil
+	}
+	scopes := tfschema.ExpectedParentScopes(parts[0], parts[1])
+	if len(scopes) == 0 {
+		return nil
+	}
+	hint.Label = strings.Join(scopes, " | ")
+	hint.Tooltip = fmt.Sprintf("The parent_id of %s is expected to refer to %s", parts[0], strings.Join(scopes, " or "))
+	return hint
+}
+
+func positionInRange(pos lsp.Position, r lsp.Range) bool {
+	return !positionBefore(pos, r.Start) && !positionBefore(r.End, pos)
+}
+
+func rangeOverlaps(a lsp.Range, b lsp.Range) bool {
+	return !positionBefore(a.End, b.Start) && !positionBefore(b.End, a.Start)
+}
+
+func positionBefore(a lsp.Position, b lsp.Position) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Character < b.Character
+}
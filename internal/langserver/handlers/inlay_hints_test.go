@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
+)
+
+func TestInlayHint_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/inlayHint",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"range": {
+				"start": {"line": 0, "character": 0},
+				"end": {"line": 0, "character": 0}
+			}
+		}`, TempDir(t).URI()),
+	}, session.SessionNotInitialized.Err())
+}
+
+func TestInlayHint_basic(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/inlayHint",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"range": {
+				"start": {"line": 0, "character": 0},
+				"end": {"line": 30, "character": 0}
+			}
+		}`, tmpDir.URI()),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"position": {"line": 2, "character": 67},
+				"label": "subscription",
+				"kind": 1,
+				"tooltip": "The parent_id refers to Microsoft.Resources/subscriptions",
+				"paddingLeft": true
+			},
+			{
+				"position": {"line": 9, "character": 34},
+				"label": "resource group",
+				"kind": 1,
+				"tooltip": "The parent_id refers to Microsoft.Resources/resourceGroups",
+				"paddingLeft": true
+			},
+			{
+				"position": {"line": 13, "character": 14},
+				"label": ": object",
+				"kind": 1
+			},
+			{
+				"position": {"line": 14, "character": 18},
+				"label": ": object",
+				"kind": 1
+			},
+			{
+				"position": {"line": 15, "character": 23},
+				"label": ": array",
+				"kind": 1
+			},
+			{
+				"position": {"line": 17, "character": 16},
+				"label": ": object",
+				"kind": 1
+			},
+			{
+				"position": {"line": 18, "character": 15},
+				"label": ": boolean",
+				"kind": 1
+			},
+			{
+				"position": {"line": 19, "character": 19},
+				"label": ": string (enum)",
+				"kind": 1
+			},
+			{
+				"position": {"line": 27, "character": 25},
+				"label": "Microsoft.Network/virtualNetworks",
+				"kind": 1,
+				"tooltip": "The parent_id of Microsoft.Network/virtualNetworks/subnets is expected to refer to Microsoft.Network/virtualNetworks",
+				"paddingLeft": true
+			}
+		]
+	}`)
+}
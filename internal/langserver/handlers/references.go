@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+)
+
+func (svc *service) References(ctx context.Context, params lsp.ReferenceParams) ([]lsp.Location, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params.TextDocumentPositionParams, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.Printf("Looking for references at %q -> %#v", doc.Filename(), fPos.Position())
+	origins := mod.ReferenceOriginsTargetingPos(doc.Filename(), fPos.Position(), params.Context.IncludeDeclaration)
+
+	return ilsp.RefOriginsToLocations(origins), nil
+}
+
+func (svc *service) DocumentHighlight(ctx context.Context, params lsp.DocumentHighlightParams) ([]lsp.DocumentHighlight, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params.TextDocumentPositionParams, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	highlights := make([]lsp.DocumentHighlight, 0)
+	address := mod.AddressAtPos(doc.Filename(), fPos.Position())
+	if address == "" {
+		return highlights, nil
+	}
+
+	for _, declaration := range mod.DeclarationsByAddress(address) {
+		if declaration.DefRange.Filename != doc.Filename() {
+			continue
+		}
+		highlights = append(highlights, lsp.DocumentHighlight{
+			Range: ilsp.HCLRangeToLSP(declaration.DefRange),
+			Kind:  lsp.Text,
+		})
+	}
+	for _, ref := range mod.FileReferences(doc.Filename()) {
+		if ref.Address != address {
+			continue
+		}
+		highlights = append(highlights, lsp.DocumentHighlight{
+			Range: ilsp.HCLRangeToLSP(ref.Range),
+			Kind:  lsp.Read,
+		})
+	}
+
+	return highlights, nil
+}
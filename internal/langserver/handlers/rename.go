@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/uri"
+)
+
+func (svc *service) PrepareRename(ctx context.Context, params lsp.PrepareRenameParams) (*lsp.Range, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params.TextDocumentPositionParams, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	target := mod.RenameTargetAtPos(doc.Filename(), fPos.Position())
+	if target == nil {
+		return nil, nil
+	}
+
+	rng := ilsp.HCLRangeToLSP(target.Range)
+	return &rng, nil
+}
+
+func (svc *service) Rename(ctx context.Context, params lsp.RenameParams) (*lsp.WorkspaceEdit, error) {
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(lsp.TextDocumentPositionParams{
+		TextDocument: params.TextDocument,
+		Position:     params.Position,
+	}, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.folders.Index(doc.Dir()).Module(doc.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	svc.logger.Printf("Renaming resource at %q -> %#v to %q", doc.Filename(), fPos.Position(), params.NewName)
+	edits, err := mod.RenameResource(doc.Filename(), fPos.Position(), params.NewName)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string][]lsp.TextEdit)
+	for filename, fileEdits := range edits {
+		fileUri := uri.FromPath(filepath.Join(mod.Path, filename))
+		for _, edit := range fileEdits {
+			changes[fileUri] = append(changes[fileUri], lsp.TextEdit{
+				Range:   ilsp.HCLRangeToLSP(edit.Range),
+				NewText: edit.NewText,
+			})
+		}
+	}
+
+	return &lsp.WorkspaceEdit{
+		Changes: changes,
+	}, nil
+}
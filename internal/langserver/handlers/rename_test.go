@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+)
+
+func TestRename_crossFile(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+	copyTestdataModule(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"textDocument": {
+				"rename": {
+					"prepareSupport": true
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/prepareRename",
+		ReqParams: buildReqParamsHover(1, 29, tmpDir.URI()),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"start": {"line": 0, "character": 27},
+			"end": {"line": 0, "character": 29}
+		}
+	}`)
+
+	// the resource type can't be renamed
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "textDocument/prepareRename",
+		ReqParams: buildReqParamsHover(1, 15, tmpDir.URI()),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": null
+	}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 0,
+				"character": 28
+			},
+			"newName": "resource_group"
+		}`, tmpDir.URI()),
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 5,
+		"result": {
+			"changes": {
+				"%[1]s/main.tf": [
+					{
+						"range": {
+							"start": {"line": 0, "character": 27},
+							"end": {"line": 0, "character": 29}
+						},
+						"newText": "resource_group"
+					},
+					{
+						"range": {
+							"start": {"line": 7, "character": 25},
+							"end": {"line": 7, "character": 27}
+						},
+						"newText": "resource_group"
+					},
+					{
+						"range": {
+							"start": {"line": 4, "character": 1},
+							"end": {"line": 4, "character": 1}
+						},
+						"newText": "\n\nmoved {\n  from = azapi_resource.rg\n  to   = azapi_resource.resource_group\n}"
+					}
+				],
+				"%[1]s/vnet.tf": [
+					{
+						"range": {
+							"start": {"line": 2, "character": 29},
+							"end": {"line": 2, "character": 31}
+						},
+						"newText": "resource_group"
+					},
+					{
+						"range": {
+							"start": {"line": 4, "character": 29},
+							"end": {"line": 4, "character": 31}
+						},
+						"newText": "resource_group"
+					}
+				]
+			}
+		}
+	}`, tmpDir.URI()))
+}
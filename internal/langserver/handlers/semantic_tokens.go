@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl-lang/lang"
+)
+
+func (svc *service) TextDocumentSemanticTokensFull(ctx context.Context, params lsp.SemanticTokensParams) (lsp.SemanticTokens, error) {
+	tks := lsp.SemanticTokens{
+		Data: make([]uint32, 0),
+	}
+
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return tks, err
+	}
+
+	fs, err := lsctx.DocumentStorage(ctx)
+	if err != nil {
+		return tks, err
+	}
+
+	doc, err := fs.GetDocument(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return tks, err
+	}
+
+	file, err := doc.ParsedFile()
+	if err != nil {
+		return tks, err
+	}
+
+	body := file.Body
+	if body == nil {
+		return tks, nil
+	}
+
+	tokens := make([]lang.SemanticToken, 0)
+	for _, block := range body.Blocks {
+		tokens = append(tokens, tfschema.BodySemanticTokens(block, file.Src)...)
+	}
+
+	te := &ilsp.TokenEncoder{
+		Lines:      doc.Lines(),
+		Tokens:     tokens,
+		ClientCaps: cc.TextDocument.SemanticTokens,
+	}
+	tks.Data = te.Encode()
+
+	return tks, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync"
 
 	lsctx "github.com/Azure/ms-terraform-lsp/internal/context"
 	"github.com/Azure/ms-terraform-lsp/internal/filesystem"
@@ -13,7 +14,9 @@ import (
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
 	"github.com/Azure/ms-terraform-lsp/internal/telemetry"
+	"github.com/Azure/ms-terraform-lsp/internal/workspace"
 	"github.com/creachadair/jrpc2"
 	"github.com/creachadair/jrpc2/code"
 	rpch "github.com/creachadair/jrpc2/handler"
@@ -28,12 +31,20 @@ type service struct {
 	stopSession context.CancelFunc
 
 	fs             filesystem.Filesystem
-	telemetry      telemetry.Sender
+	folders        *workspace.Folders
 	server         session.Server
 	diagsNotifier  *diagnostics.Notifier
 	clientCaller   session.ClientCaller
 	clientNotifier session.ClientNotifier
 
+	settings   settings.Settings
+	settingsMu sync.RWMutex
+	// telemetry is replaced when the telemetry is turned on or off by the settings
+	telemetry   telemetry.Sender
+	telemetryMu sync.RWMutex
+	// telemetryVersion is the telemetry version supported by the client, 0 if it's not supported
+	telemetryVersion int
+
 	additionalHandlers map[string]rpch.Func
 }
 
@@ -50,6 +61,8 @@ func NewSession(srvCtx context.Context) session.Session {
 		sessCtx:     sessCtx,
 		stopSession: stopSession,
 		telemetry:   &telemetry.NoopSender{},
+
+		settings: settings.DefaultSettings(),
 	}
 }
 
@@ -69,8 +82,9 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 		return nil, fmt.Errorf("Unable to prepare session: %w", err)
 	}
 
-	svc.telemetry = &telemetry.NoopSender{Logger: svc.logger}
+	svc.setTelemetry(&telemetry.NoopSender{Logger: svc.logger})
 	svc.fs.SetLogger(svc.logger)
+	svc.folders = workspace.NewFolders(svc.fs)
 
 	lh := LogHandler(svc.logger)
 	cc := &lsp.ClientCapabilities{}
@@ -100,7 +114,9 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 
-			return handle(ctx, req, Initialized)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.Initialized)
 		},
 		"textDocument/didChange": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
@@ -109,6 +125,8 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			}
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
 			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
+			ctx = lsctx.WithSettings(ctx, svc.currentSettings())
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
 			return handle(ctx, req, TextDocumentDidChange)
 		},
 		"textDocument/didOpen": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
@@ -118,6 +136,8 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			}
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
 			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
+			ctx = lsctx.WithSettings(ctx, svc.currentSettings())
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
 			return handle(ctx, req, lh.TextDocumentDidOpen)
 		},
 		"textDocument/didSave": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
@@ -136,6 +156,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
 			return handle(ctx, req, TextDocumentDidClose)
 		},
 		"textDocument/completion": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
@@ -146,7 +167,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
 			ctx = ilsp.WithClientCapabilities(ctx, cc)
-			ctx = lsctx.WithTelemetry(ctx, svc.telemetry)
+			ctx = lsctx.WithTelemetry(ctx, svc.currentTelemetry())
 
 			return handle(ctx, req, svc.TextDocumentComplete)
 		},
@@ -159,10 +180,170 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
 			ctx = ilsp.WithClientCapabilities(ctx, cc)
 			ctx = ilsp.ContextWithClientName(ctx, &clientName)
-			ctx = lsctx.WithTelemetry(ctx, svc.telemetry)
+			ctx = lsctx.WithTelemetry(ctx, svc.currentTelemetry())
 
 			return handle(ctx, req, svc.TextDocumentHover)
 		},
+		"textDocument/definition": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.GoToDefinition)
+		},
+		"textDocument/declaration": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.GoToDeclaration)
+		},
+		"textDocument/references": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.References)
+		},
+		"textDocument/documentHighlight": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.DocumentHighlight)
+		},
+		"textDocument/prepareRename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.PrepareRename)
+		},
+		"textDocument/rename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.Rename)
+		},
+		"textDocument/documentSymbol": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.TextDocumentSymbol)
+		},
+		"workspace/didChangeConfiguration": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
+
+			return handle(ctx, req, svc.WorkspaceDidChangeConfiguration)
+		},
+		"workspace/didChangeWatchedFiles": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
+
+			return handle(ctx, req, WorkspaceDidChangeWatchedFiles)
+		},
+		"workspace/didChangeWorkspaceFolders": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.WorkspaceDidChangeWorkspaceFolders)
+		},
+		"workspace/symbol": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+			ctx = lsctx.WithSettings(ctx, svc.currentSettings())
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
+
+			return handle(ctx, req, svc.WorkspaceSymbol)
+		},
+		"textDocument/codeLens": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+
+			return handle(ctx, req, svc.TextDocumentCodeLens)
+		},
+		"textDocument/documentLink": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.TextDocumentLink)
+		},
+		"textDocument/semanticTokens/full": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = ilsp.WithClientCapabilities(ctx, cc)
+
+			return handle(ctx, req, svc.TextDocumentSemanticTokensFull)
+		},
+		"textDocument/inlayHint": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithSettings(ctx, svc.currentSettings())
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
+
+			return handle(ctx, req, svc.TextDocumentInlayHint)
+		},
 		"textDocument/codeAction": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
@@ -171,6 +352,8 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			ctx = ilsp.WithClientCapabilities(ctx, cc)
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
+			ctx = lsctx.WithSettings(ctx, svc.currentSettings())
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
 
 			return handle(ctx, req, lh.TextDocumentCodeAction)
 		},
@@ -183,7 +366,9 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = lsctx.WithClientCaller(ctx, svc.clientCaller)
 			ctx = lsctx.WithClientNotifier(ctx, svc.clientNotifier)
 			ctx = lsctx.WithDocumentStorage(ctx, svc.fs)
-			ctx = lsctx.WithTelemetry(ctx, svc.telemetry)
+			ctx = lsctx.WithTelemetry(ctx, svc.currentTelemetry())
+			ctx = lsctx.WithSettings(ctx, svc.currentSettings())
+			ctx = lsctx.WithWorkspaceFolders(ctx, svc.folders)
 
 			return handle(ctx, req, svc.WorkspaceExecuteCommand)
 		},
@@ -239,10 +424,34 @@ func (svc *service) setupTelemetry(version int, notifier session.ClientNotifier)
 		return err
 	}
 
-	svc.telemetry = t
+	svc.setTelemetry(t)
 	return nil
 }
 
+func (svc *service) currentTelemetry() telemetry.Sender {
+	svc.telemetryMu.RLock()
+	defer svc.telemetryMu.RUnlock()
+	return svc.telemetry
+}
+
+func (svc *service) setTelemetry(t telemetry.Sender) {
+	svc.telemetryMu.Lock()
+	defer svc.telemetryMu.Unlock()
+	svc.telemetry = t
+}
+
+func (svc *service) currentSettings() settings.Settings {
+	svc.settingsMu.RLock()
+	defer svc.settingsMu.RUnlock()
+	return svc.settings
+}
+
+func (svc *service) setSettings(s settings.Settings) {
+	svc.settingsMu.Lock()
+	defer svc.settingsMu.Unlock()
+	svc.settings = s
+}
+
 func (svc *service) Finish(_ jrpc2.Assigner, status jrpc2.ServerStatus) {
 	if status.Closed || status.Err != nil {
 		svc.logger.Printf("session stopped unexpectedly (err: %v)", status.Err)
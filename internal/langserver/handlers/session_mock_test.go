@@ -10,6 +10,7 @@ import (
 
 	"github.com/Azure/ms-terraform-lsp/internal/filesystem"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/session"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
 	"github.com/creachadair/jrpc2/handler"
 )
 
@@ -47,6 +48,7 @@ func (ms *mockSession) new(srvCtx context.Context) session.Session {
 		stopSession:        ms.stop,
 		fs:                 fs,
 		additionalHandlers: handlers,
+		settings:           settings.DefaultSettings(),
 	}
 
 	return svc
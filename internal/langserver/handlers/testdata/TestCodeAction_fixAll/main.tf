@@ -0,0 +1,27 @@
+resource "azapi_resource" "script" {
+  name      = "example"
+  parent_id = azurerm_resource_group.test.id
+  type      = "Microsoft.Resources/deploymentScripts@2020-10-01"
+  body = {
+    kind     = "azurecli"
+    location = "westus"
+    properties = {
+      azCliVersion      = "2.0.80"
+      retentionInterval = "P1D"
+    }
+  }
+}
+
+resource "azapi_resource" "factory" {
+  name      = "example"
+  parent_id = azurerm_resource_group.test.id
+  type      = "Microsoft.DataFactory/factories@2018-06-01"
+  body = {
+    identity1 = {
+      type = "SystemAssigned"
+    }
+    properties = {
+      createTime = "2024-01-01T00:00:00Z"
+    }
+  }
+}
@@ -0,0 +1,16 @@
+resource "azapi_resource" "rg" {
+  type     = "Microsoft.Resources/resourceGroups@2020-06-01"
+  name     = "example"
+  location = "westus"
+}
+
+resource "azapi_update_resource" "storage" {
+  type      = "Microsoft.Storage/storageAccounts@2020-08-01-preview"
+  parent_id = azapi_resource.rg.id
+  name      = "example"
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "example"
+  location = "westus"
+}
@@ -0,0 +1,5 @@
+resource "azapi_resource" "rg" {
+  type     = "Microsoft.Resources/resourceGroups@2021-04-01"
+  name     = "example"
+  location = "westus"
+}
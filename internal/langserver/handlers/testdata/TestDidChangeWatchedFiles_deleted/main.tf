@@ -0,0 +1,9 @@
+resource "azapi_resource" "rg" {
+  type     = "Microsoft.Resources/resourceGroups@2021-04-01"
+  name     = "example"
+  location = "westus"
+}
+
+output "rg_id" {
+  value = azapi_resource.rg.id
+}
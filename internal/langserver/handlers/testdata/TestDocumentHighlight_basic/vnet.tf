@@ -0,0 +1,6 @@
+resource "azapi_resource" "vnet" {
+  type      = "Microsoft.Network/virtualNetworks@2024-01-01"
+  parent_id = azapi_resource.rg.id
+  name      = "example"
+  location  = azapi_resource.rg.location
+}
@@ -0,0 +1,12 @@
+resource "azapi_resource" "vnet" {
+  type      = "Microsoft.Network/virtualNetworks@2024-01-01"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  name      = "example"
+}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "example"
+  }
+}
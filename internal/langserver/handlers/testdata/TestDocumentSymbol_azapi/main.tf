@@ -0,0 +1,18 @@
+resource "azapi_resource" "vnet" {
+  type      = "Microsoft.Network/virtualNetworks@2024-01-01"
+  parent_id = azapi_resource.rg.id
+  name      = "example"
+  body = {
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["10.0.0.0/16"]
+      }
+      enableDdosProtection = false
+    }
+  }
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "example"
+  location = "westus"
+}
@@ -0,0 +1,30 @@
+resource "azapi_resource" "rg" {
+  type      = "Microsoft.Resources/resourceGroups@2021-04-01"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  name      = "example"
+  location  = "westus"
+}
+
+resource "azapi_resource" "vnet" {
+  type      = "Microsoft.Network/virtualNetworks@2024-01-01"
+  parent_id = azapi_resource.rg.id
+  name      = "example"
+  location  = "westus"
+  body = {
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["10.0.0.0/16"]
+      }
+      encryption = {
+        enabled     = true
+        enforcement = "AllowUnencrypted"
+      }
+    }
+  }
+}
+
+resource "azapi_resource" "subnet" {
+  type      = "Microsoft.Network/virtualNetworks/subnets@2024-01-01"
+  parent_id = var.vnet_id
+  name      = "example"
+}
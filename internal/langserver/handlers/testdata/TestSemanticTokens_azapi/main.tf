@@ -0,0 +1,13 @@
+resource "azapi_resource" "dataflow" {
+  type      = "Microsoft.DataFactory/factories/dataflows@2018-06-01"
+  parent_id = azurerm_data_factory.test.id
+  name      = "example"
+  body = {
+    etag = "foo"
+    properties = {
+      type        = "Flowlet"
+      description = "example"
+      unknown     = true
+    }
+  }
+}
@@ -0,0 +1,14 @@
+resource "azurerm_network_security_rule" "example" {
+  name                        = "example"
+  priority                    = 100
+  direction                   = "Outbound"
+  access                      = "Allow"
+  protocol                    = "Tcp"
+  source_port_range           = "*"
+  source_port_ranges          = ["80", "443"]
+  destination_port_range      = "*"
+  source_address_prefix       = "*"
+  destination_address_prefix  = "*"
+  resource_group_name         = "example-resources"
+  network_security_group_name = "example-nsg"
+}
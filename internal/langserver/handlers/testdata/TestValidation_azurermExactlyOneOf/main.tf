@@ -0,0 +1,17 @@
+resource "azurerm_virtual_network" "example" {
+  name                = "example-network"
+  location            = "West Europe"
+  resource_group_name = "example-resources"
+}
+
+resource "azurerm_virtual_network" "both" {
+  name                = "example-network"
+  location            = "West Europe"
+  resource_group_name = "example-resources"
+  address_space       = ["10.0.0.0/16"]
+
+  ip_address_pool {
+    id                     = "example"
+    number_of_ip_addresses = "100"
+  }
+}
@@ -0,0 +1,7 @@
+resource "azurerm_storage_account" "example" {
+  name                     = "examplestorageaccount"
+  resource_group_name      = "example-resources"
+  location                 = "West Europe"
+  account_tier             = "standard"
+  account_replication_type = "LRS"
+}
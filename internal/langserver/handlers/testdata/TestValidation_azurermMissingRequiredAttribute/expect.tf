@@ -0,0 +1,4 @@
+resource "azurerm_resource_group" "example" {
+  location = ""
+  name = "example-resources"
+}
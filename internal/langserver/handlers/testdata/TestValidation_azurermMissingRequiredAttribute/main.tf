@@ -0,0 +1,3 @@
+resource "azurerm_resource_group" "example" {
+  name = "example-resources"
+}
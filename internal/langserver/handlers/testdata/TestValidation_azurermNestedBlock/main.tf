@@ -0,0 +1,15 @@
+resource "azurerm_storage_account" "example" {
+  name                     = "examplestorageaccount"
+  resource_group_name      = "example-resources"
+  location                 = "West Europe"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  network_rules {
+    ip_rule = ["100.0.0.1"]
+  }
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
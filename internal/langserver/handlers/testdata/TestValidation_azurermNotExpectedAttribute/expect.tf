@@ -0,0 +1,7 @@
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "West Europe"
+  tags = {
+    env = "test"
+  }
+}
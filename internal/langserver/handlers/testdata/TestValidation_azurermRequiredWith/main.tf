@@ -0,0 +1,13 @@
+resource "azurerm_storage_account" "example" {
+  name                     = "examplestorageaccount"
+  resource_group_name      = "example-resources"
+  location                 = "West Europe"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  blob_properties {
+    restore_policy {
+      days = 7
+    }
+  }
+}
@@ -0,0 +1,22 @@
+resource "azapi_data_plane_resource" "valid" {
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@2024-06-01"
+  parent_id = "example.azconfig.io"
+  name      = "key"
+  body = {
+    properties = {
+      value       = "value"
+      contentType = "text/plain"
+    }
+  }
+}
+
+resource "azapi_data_plane_resource" "invalid" {
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@2024-06-01"
+  parent_id = "example.azconfig.io"
+  name      = "key"
+  body = {
+    properties = {
+      valu = "value"
+    }
+  }
+}
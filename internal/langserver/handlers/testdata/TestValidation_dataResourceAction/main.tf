@@ -0,0 +1,18 @@
+data "azapi_resource_action" "valid" {
+  type        = "Microsoft.Storage/storageAccounts@2023-05-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  action      = "regenerateKey"
+  body = {
+    keyName = "key1"
+  }
+}
+
+data "azapi_resource_action" "invalid" {
+  type        = "Microsoft.Storage/storageAccounts@2023-05-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  action      = "regenerateKey"
+  body = {
+    keyName = "key1"
+    keyType = "key"
+  }
+}
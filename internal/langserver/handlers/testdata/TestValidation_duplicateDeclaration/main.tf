@@ -0,0 +1,9 @@
+variable "location" {
+  type = string
+}
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Resources/resourceGroups@2024-03-01"
+  name      = "example"
+  location  = var.location
+}
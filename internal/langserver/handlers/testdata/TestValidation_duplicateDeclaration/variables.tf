@@ -0,0 +1,13 @@
+variable "location" {
+  type = string
+}
+
+locals {
+  name = "example"
+}
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Resources/resourceGroups@2024-03-01"
+  name      = local.name
+  location  = var.location
+}
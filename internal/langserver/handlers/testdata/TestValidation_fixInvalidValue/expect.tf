@@ -0,0 +1,13 @@
+resource "azapi_resource" "test" {
+  name      = "example"
+  parent_id = azurerm_resource_group.test.id
+  type      = "Microsoft.Resources/deploymentScripts@2020-10-01"
+  body = {
+    kind     = "AzureCLI"
+    location = "westus"
+    properties = {
+      azCliVersion      = "2.0.80"
+      retentionInterval = "P1D"
+    }
+  }
+}
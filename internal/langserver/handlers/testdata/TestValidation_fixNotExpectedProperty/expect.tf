@@ -0,0 +1,10 @@
+resource "azapi_resource" "test" {
+  name      = "example"
+  parent_id = azurerm_resource_group.test.id
+  type      = "Microsoft.DataFactory/factories@2018-06-01"
+  body = {
+    identity = {
+      type = "SystemAssigned"
+    }
+  }
+}
@@ -0,0 +1,11 @@
+resource "azapi_resource" "test" {
+  name      = "example"
+  parent_id = azurerm_resource_group.test.id
+  type      = "Microsoft.DataFactory/factories@2018-06-01"
+  body = jsonencode({
+    "properties": {
+      "createTime": "2024-01-01T00:00:00Z",
+      "publicNetworkAccess": "Enabled"
+    }
+  })
+}
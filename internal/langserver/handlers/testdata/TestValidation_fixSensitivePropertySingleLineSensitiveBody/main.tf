@@ -0,0 +1,13 @@
+resource "azapi_resource" "server" {
+  type      = "Microsoft.Sql/servers@2021-11-01"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  name      = "example"
+  location  = "westeurope"
+  body = jsonencode({
+    properties = {
+      administratorLogin         = "sqladmin"
+      administratorLoginPassword = var.password
+    }
+  })
+  sensitive_body = { properties = { version = "12.0" } }
+}
@@ -0,0 +1,12 @@
+resource "azapi_resource" "storageAccount" {
+  type      = "Microsoft.Storage/storageAcounts@2023-01-01"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  name      = "examplestorage"
+  location  = "westus"
+  body = {
+    kind = "StorageV2"
+    sku = {
+      name = "Standard_LRS"
+    }
+  }
+}
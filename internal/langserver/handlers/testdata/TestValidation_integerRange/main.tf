@@ -0,0 +1,13 @@
+resource "azapi_resource" "blobService" {
+  type      = "Microsoft.Storage/storageAccounts/blobServices@2023-05-01"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  name      = "default"
+  body = {
+    properties = {
+      deleteRetentionPolicy = {
+        enabled = true
+        days    = 400
+      }
+    }
+  }
+}
@@ -0,0 +1,19 @@
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName     = "example"
+    signInAudiance  = "AzureADMyOrg"
+    "owners@odata.bind" = []
+    web = {
+      homePageUrl = "https://example.com"
+      redirectUri = ["https://example.com"]
+    }
+  }
+}
+
+resource "msgraph_update_resource" "application" {
+  url = "applications/${msgraph_resource.application.id}"
+  body = {
+    displayNam = "example"
+  }
+}
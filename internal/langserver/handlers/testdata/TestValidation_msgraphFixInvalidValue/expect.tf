@@ -0,0 +1,7 @@
+resource "msgraph_resource" "policy" {
+  url = "identity/conditionalAccess/policies"
+  body = {
+    displayName = "example"
+    state       = "enabled"
+  }
+}
@@ -0,0 +1,25 @@
+resource "azapi_resource" "resourceGroup" {
+  type      = "Microsoft.Resources/resourceGroups@2021-04-01"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000"
+  name      = "example"
+  location  = "westeurope"
+}
+
+resource "azapi_resource" "virtualNetwork" {
+  type      = "Microsoft.Network/virtualNetworks@2023-04-01"
+  parent_id = azapi_resource.resourceGroup.id
+  name      = "example"
+  location  = "westeurope"
+}
+
+resource "azapi_resource" "subnet" {
+  type      = "Microsoft.Network/virtualNetworks/subnets@2023-04-01"
+  parent_id = azapi_resource.resourceGroup.id
+  name      = "example"
+}
+
+resource "azapi_resource" "managementGroup" {
+  type      = "Microsoft.Management/managementGroups@2021-04-01"
+  parent_id = azapi_resource.resourceGroup.id
+  name      = "example"
+}
@@ -0,0 +1,11 @@
+resource "azapi_resource" "storageAccount" {
+  type      = "Microsoft.Storage/storageAccounts@2015-05-01-preview"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  name      = "example"
+  location  = "westeurope"
+  body = {
+    properties = {
+      accountType = "Standard_LRS"
+    }
+  }
+}
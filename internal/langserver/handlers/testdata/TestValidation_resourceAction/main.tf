@@ -0,0 +1,34 @@
+resource "azapi_resource_action" "regenerateKey" {
+  type        = "Microsoft.Storage/storageAccounts@2023-05-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  action      = "regenerateKey"
+  body = {
+    keyNam = "key1"
+  }
+}
+
+data "azapi_resource_action" "regenerateKey" {
+  type        = "Microsoft.Storage/storageAccounts@2023-05-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  action      = "regenerateKey"
+  body = {
+  }
+}
+
+ephemeral "azapi_resource_action" "regenerateKey" {
+  type        = "Microsoft.Storage/storageAccounts@2023-05-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  action      = "regenerateKey"
+  body = {
+    keyName = "key1"
+  }
+}
+
+resource "azapi_resource_action" "restart" {
+  type        = "Microsoft.Storage/storageAccounts@2023-05-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+  method      = "PATCH"
+  body = {
+    unknown = "value"
+  }
+}
@@ -0,0 +1,16 @@
+resource "azapi_resource" "test" {
+  name      = "acctest1774"
+  parent_id = azurerm_batch_account.test.id
+  type      = "Microsoft.DataFactory/factories@2018-06-01"
+  body = jsonencode({
+    # ms-terraform:ignore AZ001
+    identity1 = {
+      type = "SystemAssigned"
+    }
+    location1 = "westeurope" # ms-terraform:ignore unknown-property
+    tags1 = {
+    }
+    properties = {
+    }
+  })
+}
@@ -0,0 +1,9 @@
+resource "azapi_update_resource" "test" {
+  type        = "Microsoft.DataFactory/factories@2018-06-01"
+  resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.DataFactory/factories/example"
+  body = {
+    properties = {
+      publicNetworkAcces = "Disabled"
+    }
+  }
+}
@@ -0,0 +1,15 @@
+resource "azurerm_resource_group" "rg" {
+  name     = "example"
+  location = "westus"
+}
+
+resource "azurerm_storage_account" "sa" {
+  name                = "example"
+  resource_group_name = azurerm_resource_group.rg.name
+}
+
+resource "azapi_resource" "sa" {
+  type      = "Microsoft.Storage/storageAccounts@2023-01-01"
+  parent_id = azurerm_resource_group.rg.id
+  name      = "example2"
+}
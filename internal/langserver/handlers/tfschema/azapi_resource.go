@@ -77,7 +77,7 @@ func (r *AzAPIResource) ResourceDocumentation(typeValue string) string {
 [View Documentation](https://learn.microsoft.com/en-us/azure/templates/%s?pivots=deployment-language-terraform)`, typeValue, strings.ToLower(azureResourceType))
 }
 
-func bodyCandidates(data []byte, filename string, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, property *Property) []lsp.CompletionItem {
+func bodyCandidates(file *parser.File, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, property *Property) []lsp.CompletionItem {
 	if attribute.Expr != nil {
 		if _, ok := attribute.Expr.(*hclsyntax.LiteralValueExpr); ok && parser.ToLiteral(attribute.Expr) == nil {
 			if property != nil {
@@ -91,16 +91,12 @@ func bodyCandidates(data []byte, filename string, block *hclsyntax.Block, attrib
 		return nil
 	}
 
-	hclNode := parser.JsonEncodeExpressionToHclNode(data, attribute.Expr)
-	if hclNode == nil {
-		tokens, _ := hclsyntax.LexExpression(data[attribute.Expr.Range().Start.Byte:attribute.Expr.Range().End.Byte], filename, attribute.Expr.Range().Start)
-		hclNode = parser.BuildHclNode(tokens)
-	}
+	hclNode := file.AttributeHclNode(attribute, true)
 	if hclNode == nil {
 		return nil
 	}
 
-	return buildAzAPIBodyCandidates(hclNode, filename, pos, bodyDef)
+	return buildAzAPIBodyCandidates(hclNode, file.Filename, pos, bodyDef)
 }
 
 func buildAzAPIBodyCandidates(hclNode *parser.HclNode, filename string, pos hcl.Pos, def types.TypeBase) []lsp.CompletionItem {
@@ -306,7 +302,7 @@ func dataSourceHttpMethodCandidates(_ *string, r lsp.Range) []lsp.CompletionItem
 	}, r, true)
 }
 
-func actionCandidates(_ []byte, _ string, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, _ *Property) []lsp.CompletionItem {
+func actionCandidates(_ *parser.File, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, _ *Property) []lsp.CompletionItem {
 	typeValue := parser.ExtractAzureResourceType(block)
 	if typeValue == nil {
 		return nil
@@ -333,17 +329,13 @@ func actionCandidates(_ []byte, _ string, block *hclsyntax.Block, attribute *hcl
 	return valueCandidates(values, editRangeFromExprRange(attribute.Expr, pos), false)
 }
 
-func azapiBodyHover(block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, data []byte) *lsp.Hover {
+func azapiBodyHover(block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, file *parser.File) *lsp.Hover {
 	bodyDef := BodyDefinitionFromBlock(block)
 	if bodyDef == nil {
 		return nil
 	}
 
-	hclNode := parser.JsonEncodeExpressionToHclNode(data, attribute.Expr)
-	if hclNode == nil {
-		tokens, _ := hclsyntax.LexExpression(data[attribute.Expr.Range().Start.Byte:attribute.Expr.Range().End.Byte], "main.tf", attribute.Expr.Range().Start)
-		hclNode = parser.BuildHclNode(tokens)
-	}
+	hclNode := file.AttributeHclNode(attribute, true)
 	if hclNode == nil {
 		return nil
 	}
@@ -9,8 +9,8 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
-func msgraphBodyHover(block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, data []byte) *lsp.Hover {
-	urlValue := parser.ExtractMSGraphUrl(block, data)
+func msgraphBodyHover(block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, file *parser.File) *lsp.Hover {
+	urlValue := parser.ExtractMSGraphUrl(block, file.Src)
 	apiVersion := "v1.0"
 	if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
 		apiVersion = *v
@@ -21,9 +21,7 @@ func msgraphBodyHover(block *hclsyntax.Block, attribute *hclsyntax.Attribute, po
 		return nil
 	}
 
-	tokens, _ := hclsyntax.LexExpression(data[attribute.Expr.Range().Start.Byte:attribute.Expr.Range().End.Byte], "main.tf", attribute.Expr.Range().Start)
-	hclNode := parser.BuildHclNode(tokens)
-
+	hclNode := file.AttributeHclNode(attribute, false)
 	if hclNode == nil {
 		return nil
 	}
@@ -0,0 +1,156 @@
+package tfschema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/schema"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/msgraph"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	msgraphtypes "github.com/ms-henglu/go-msgraph-types/types"
+)
+
+// BodyInlayHints returns the inlay hints which show the schema type after the property keys defined in the body
+// and sensitive_body of azapi and msgraph resources, e.g. `: string (enum)` or `: object`
+func BodyInlayHints(block *hclsyntax.Block, data []byte) []lsp.InlayHint {
+	if block == nil || len(block.Labels) == 0 {
+		return nil
+	}
+
+	var typeName func(hclNodes []*parser.HclNode) string
+	switch {
+	case strings.HasPrefix(block.Labels[0], "azapi_"):
+		bodyDef := BodyDefinitionFromBlock(block)
+		if bodyDef == nil {
+			return nil
+		}
+		typeName = func(hclNodes []*parser.HclNode) string {
+			return azapiTypeName(schema.GetAzAPIDef(bodyDef.AsTypeBase(), hclNodes, 0))
+		}
+	case strings.HasPrefix(block.Labels[0], "msgraph_"):
+		apiVersion := "v1.0"
+		if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
+			apiVersion = *v
+		}
+		bodyDef := msgraph.SchemaLoader.GetResourceDefinition(apiVersion, parser.ExtractMSGraphUrl(block, data))
+		if bodyDef == nil {
+			return nil
+		}
+		typeName = func(hclNodes []*parser.HclNode) string {
+			return msgraphTypeName(schema.GetMSGraphDef(bodyDef.AsTypeBase(), hclNodes, 0))
+		}
+	default:
+		return nil
+	}
+
+	hints := make([]lsp.InlayHint, 0)
+	for _, name := range []string{"body", "sensitive_body"} {
+		attribute := parser.AttributeWithName(block, name)
+		if attribute == nil {
+			continue
+		}
+		hclNode := parser.ExpressionToHclNode(data, attribute.Expr)
+		if hclNode == nil {
+			continue
+		}
+		if dummy, ok := hclNode.Children["dummy"]; ok {
+			hints = append(hints, bodyInlayHints([]*parser.HclNode{dummy}, typeName)...)
+		}
+	}
+
+	sort.Slice(hints, func(i, j int) bool {
+		if hints[i].Position.Line != hints[j].Position.Line {
+			return hints[i].Position.Line < hints[j].Position.Line
+		}
+		return hints[i].Position.Character < hints[j].Position.Character
+	})
+	return hints
+}
+
+// bodyInlayHints returns the inlay hints of the children of the last hcl node in the path,
+// the array items don't have keys, so only their nested properties have hints
+func bodyInlayHints(hclNodes []*parser.HclNode, typeName func(hclNodes []*parser.HclNode) string) []lsp.InlayHint {
+	parent := hclNodes[len(hclNodes)-1]
+	if parent.Value != nil {
+		return nil
+	}
+	isArray := parent.IsValueArray()
+
+	hints := make([]lsp.InlayHint, 0)
+	for _, child := range parent.Children {
+		path := append(append(make([]*parser.HclNode, 0, len(hclNodes)+1), hclNodes...), child)
+		if !isArray && !child.KeyRange.Empty() {
+			if name := typeName(path); name != "" {
+				hints = append(hints, lsp.InlayHint{
+					Position: ilsp.HCLPosToLSP(child.KeyRange.End),
+					Label:    ": " + name,
+					Kind:     lsp.InlayHintKindType,
+				})
+			}
+		}
+		hints = append(hints, bodyInlayHints(path, typeName)...)
+	}
+	return hints
+}
+
+// azapiTypeName returns the type name of the definitions, the names are joined with `|` if they're different
+func azapiTypeName(defs []*types.TypeBase) string {
+	names := make([]string, 0)
+	isEnum := false
+	for _, def := range defs {
+		if def == nil || *def == nil {
+			continue
+		}
+		name := schema.GetAzAPITypeName(def)
+		if name == "" {
+			continue
+		}
+		if name == "string" && len(schema.GetAzAPIAllowedValues(def)) != 0 {
+			isEnum = true
+		}
+		names = append(names, name)
+	}
+	return joinTypeNames(names, isEnum)
+}
+
+// msgraphTypeName returns the type name of the definitions, the names are joined with `|` if they're different
+func msgraphTypeName(defs []*msgraphtypes.TypeBase) string {
+	names := make([]string, 0)
+	isEnum := false
+	for _, def := range defs {
+		if def == nil || *def == nil {
+			continue
+		}
+		name := schema.GetMSGraphTypeName(def)
+		if name == "" {
+			continue
+		}
+		if name == "string" && len(schema.GetMSGraphAllowedValues(def)) != 0 {
+			isEnum = true
+		}
+		names = append(names, name)
+	}
+	return joinTypeNames(names, isEnum)
+}
+
+func joinTypeNames(names []string, isEnum bool) string {
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	unique := make([]string, 0)
+	for i, name := range names {
+		if i == 0 || name != names[i-1] {
+			unique = append(unique, name)
+		}
+	}
+	res := strings.Join(unique, " | ")
+	if isEnum && len(unique) == 1 {
+		res += " (enum)"
+	}
+	return res
+}
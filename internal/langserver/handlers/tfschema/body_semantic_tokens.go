@@ -0,0 +1,290 @@
+package tfschema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/msgraph"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	msgraphtypes "github.com/ms-henglu/go-msgraph-types/types"
+)
+
+// BodySemanticTokens returns the semantic tokens of the properties defined in the body and sensitive_body
+// of azapi and msgraph resources, the token types are decided by the schema of the body
+func BodySemanticTokens(block *hclsyntax.Block, data []byte) []lang.SemanticToken {
+	if block == nil || len(block.Labels) == 0 {
+		return nil
+	}
+
+	var walk func(hclNode *parser.HclNode) []lang.SemanticToken
+	switch {
+	case strings.HasPrefix(block.Labels[0], "azapi_"):
+		bodyDef := BodyDefinitionFromBlock(block)
+		if bodyDef == nil {
+			return nil
+		}
+		walk = func(hclNode *parser.HclNode) []lang.SemanticToken {
+			return azapiBodySemanticTokens(hclNode, bodyDef.AsTypeBase())
+		}
+	case strings.HasPrefix(block.Labels[0], "msgraph_"):
+		apiVersion := "v1.0"
+		if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
+			apiVersion = *v
+		}
+		bodyDef := msgraph.SchemaLoader.GetResourceDefinition(apiVersion, parser.ExtractMSGraphUrl(block, data))
+		if bodyDef == nil {
+			return nil
+		}
+		walk = func(hclNode *parser.HclNode) []lang.SemanticToken {
+			return msgraphBodySemanticTokens(hclNode, bodyDef.AsTypeBase())
+		}
+	default:
+		return nil
+	}
+
+	tokens := make([]lang.SemanticToken, 0)
+	for _, name := range []string{"body", "sensitive_body"} {
+		attribute := parser.AttributeWithName(block, name)
+		if attribute == nil {
+			continue
+		}
+		hclNode := parser.ExpressionToHclNode(data, attribute.Expr)
+		if hclNode == nil {
+			continue
+		}
+		if dummy, ok := hclNode.Children["dummy"]; ok {
+			tokens = append(tokens, walk(dummy)...)
+		}
+	}
+
+	for i := range tokens {
+		tokens[i].Range.Filename = block.Range().Filename
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Range.Start.Byte < tokens[j].Range.Start.Byte
+	})
+	return tokens
+}
+
+func azapiBodySemanticTokens(hclNode *parser.HclNode, typeBase *types.TypeBase) []lang.SemanticToken {
+	if typeBase == nil || *typeBase == nil || hclNode == nil {
+		return nil
+	}
+	tokens := make([]lang.SemanticToken, 0)
+	switch t := (*typeBase).(type) {
+	case *types.ArrayType:
+		if !hclNode.IsValueArray() || t.ItemType == nil {
+			break
+		}
+		for _, child := range hclNode.Children {
+			tokens = append(tokens, azapiBodySemanticTokens(child, t.ItemType.Type)...)
+		}
+	case *types.DiscriminatedObjectType:
+		if !hclNode.IsValueMap() {
+			break
+		}
+		otherProperties := make(map[string]*parser.HclNode)
+		for key, value := range hclNode.Children {
+			if def, ok := t.BaseProperties[key]; ok {
+				tokens = append(tokens, propertySemanticToken(value, def.IsReadOnly()))
+				if def.Type != nil {
+					tokens = append(tokens, azapiBodySemanticTokens(value, def.Type.Type)...)
+				}
+				continue
+			}
+			if key == t.Discriminator {
+				tokens = append(tokens, lang.SemanticToken{
+					Type:  ilsp.TokenBodyDiscriminator,
+					Range: value.KeyRange,
+				})
+				if element := t.Elements[literalValue(value)]; element != nil {
+					tokens = append(tokens, enumValueSemanticToken(value))
+				}
+				continue
+			}
+			otherProperties[key] = value
+		}
+
+		// the other properties are only known when the discriminator is valid
+		discriminator := hclNode.Children[t.Discriminator]
+		if discriminator == nil {
+			break
+		}
+		if element := t.Elements[literalValue(discriminator)]; element != nil && element.Type != nil {
+			other := &parser.HclNode{
+				Key:        hclNode.Key,
+				KeyRange:   hclNode.KeyRange,
+				Children:   otherProperties,
+				EqualRange: hclNode.EqualRange,
+				ValueRange: hclNode.ValueRange,
+			}
+			tokens = append(tokens, azapiBodySemanticTokens(other, element.Type)...)
+		}
+	case *types.ObjectType:
+		if !hclNode.IsValueMap() {
+			break
+		}
+		for key, value := range hclNode.Children {
+			if def, ok := t.Properties[key]; ok {
+				tokens = append(tokens, propertySemanticToken(value, def.IsReadOnly()))
+				if def.Type != nil {
+					tokens = append(tokens, azapiBodySemanticTokens(value, def.Type.Type)...)
+				}
+				continue
+			}
+			if t.AdditionalProperties != nil {
+				tokens = append(tokens, propertySemanticToken(value, false))
+				tokens = append(tokens, azapiBodySemanticTokens(value, t.AdditionalProperties.Type)...)
+				continue
+			}
+			tokens = append(tokens, lang.SemanticToken{
+				Type:  ilsp.TokenBodyUnknownProperty,
+				Range: value.KeyRange,
+			})
+		}
+	case *types.ResourceType:
+		if t.Body != nil {
+			return azapiBodySemanticTokens(hclNode, t.Body.Type)
+		}
+	case *types.ResourceFunctionType:
+		if t.Input != nil {
+			return azapiBodySemanticTokens(hclNode, t.Input.Type)
+		}
+	case *types.StringLiteralType:
+		if hclNode.Value != nil && literalValue(hclNode) == t.Value {
+			tokens = append(tokens, enumValueSemanticToken(hclNode))
+		}
+	case *types.UnionType:
+		if hclNode.Value != nil {
+			for _, element := range t.Elements {
+				if element == nil || element.Type == nil {
+					continue
+				}
+				if stringLiteralType, ok := (*element.Type).(*types.StringLiteralType); ok && stringLiteralType.Value == literalValue(hclNode) {
+					return []lang.SemanticToken{enumValueSemanticToken(hclNode)}
+				}
+			}
+			break
+		}
+
+		// use the element which matches the most properties
+		var best []lang.SemanticToken
+		for _, element := range t.Elements {
+			if element == nil || element.Type == nil {
+				continue
+			}
+			temp := azapiBodySemanticTokens(hclNode, element.Type)
+			if best == nil || unknownPropertyCount(temp) < unknownPropertyCount(best) {
+				best = temp
+			}
+		}
+		tokens = append(tokens, best...)
+	}
+	return tokens
+}
+
+func msgraphBodySemanticTokens(hclNode *parser.HclNode, typeBase *msgraphtypes.TypeBase) []lang.SemanticToken {
+	if typeBase == nil || *typeBase == nil || hclNode == nil {
+		return nil
+	}
+	tokens := make([]lang.SemanticToken, 0)
+	switch t := (*typeBase).(type) {
+	case *msgraphtypes.ArrayType:
+		if !hclNode.IsValueArray() || t.ItemType == nil {
+			break
+		}
+		for _, child := range hclNode.Children {
+			tokens = append(tokens, msgraphBodySemanticTokens(child, &t.ItemType.Type)...)
+		}
+	case *msgraphtypes.ObjectType:
+		if !hclNode.IsValueMap() {
+			break
+		}
+		for key, value := range hclNode.Children {
+			if def, ok := t.Properties[key]; ok {
+				tokens = append(tokens, propertySemanticToken(value, def.IsReadOnly()))
+				if def.Type != nil {
+					tokens = append(tokens, msgraphBodySemanticTokens(value, &def.Type.Type)...)
+				}
+				continue
+			}
+			if t.AdditionalProperties != nil {
+				tokens = append(tokens, propertySemanticToken(value, false))
+				tokens = append(tokens, msgraphBodySemanticTokens(value, &t.AdditionalProperties.Type)...)
+				continue
+			}
+			tokens = append(tokens, lang.SemanticToken{
+				Type:  ilsp.TokenBodyUnknownProperty,
+				Range: value.KeyRange,
+			})
+		}
+	case *msgraphtypes.ResourceType:
+		if t.Body != nil {
+			return msgraphBodySemanticTokens(hclNode, &t.Body.Type)
+		}
+	case *msgraphtypes.StringType:
+		if hclNode.Value == nil {
+			break
+		}
+		for _, value := range t.Enum {
+			if value == literalValue(hclNode) {
+				tokens = append(tokens, enumValueSemanticToken(hclNode))
+				break
+			}
+		}
+	case *msgraphtypes.UnionType:
+		var best []lang.SemanticToken
+		for _, element := range t.Elements {
+			if element == nil || element.Type == nil {
+				continue
+			}
+			temp := msgraphBodySemanticTokens(hclNode, &element.Type)
+			if best == nil || unknownPropertyCount(temp) < unknownPropertyCount(best) {
+				best = temp
+			}
+		}
+		tokens = append(tokens, best...)
+	}
+	return tokens
+}
+
+func propertySemanticToken(hclNode *parser.HclNode, readOnly bool) lang.SemanticToken {
+	token := lang.SemanticToken{
+		Type:      ilsp.TokenBodyProperty,
+		Modifiers: []lang.SemanticTokenModifier{},
+		Range:     hclNode.KeyRange,
+	}
+	if readOnly {
+		token.Modifiers = append(token.Modifiers, ilsp.TokenModifierBodyReadOnly)
+	}
+	return token
+}
+
+func enumValueSemanticToken(hclNode *parser.HclNode) lang.SemanticToken {
+	return lang.SemanticToken{
+		Type:  ilsp.TokenBodyEnumValue,
+		Range: hclNode.ValueRange,
+	}
+}
+
+func unknownPropertyCount(tokens []lang.SemanticToken) int {
+	count := 0
+	for _, token := range tokens {
+		if token.Type == ilsp.TokenBodyUnknownProperty {
+			count++
+		}
+	}
+	return count
+}
+
+// literalValue returns the value of the hcl node without the quotes
+func literalValue(hclNode *parser.HclNode) string {
+	if hclNode == nil || hclNode.Value == nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSuffix(strings.TrimSpace(*hclNode.Value), `"`), `"`)
+}
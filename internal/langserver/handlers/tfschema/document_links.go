@@ -0,0 +1,123 @@
+package tfschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	"github.com/Azure/ms-terraform-lsp/internal/msgraph"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	provider_schema "github.com/Azure/ms-terraform-lsp/provider-schema"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// DocumentLinks returns the links to the documentation of the resource defined in the block:
+// the azapi type links to the ARM template reference, the msgraph url links to the Graph docs
+// and the azurerm resource type links to the terraform registry
+func DocumentLinks(block *hclsyntax.Block, data []byte) []lang.Link {
+	if block == nil || len(block.Labels) == 0 {
+		return nil
+	}
+
+	links := make([]lang.Link, 0)
+	switch {
+	case strings.HasPrefix(block.Labels[0], "azapi_"):
+		if link := azapiTypeLink(block); link != nil {
+			links = append(links, *link)
+		}
+	case strings.HasPrefix(block.Labels[0], "msgraph_"):
+		if link := msgraphUrlLink(block, data); link != nil {
+			links = append(links, *link)
+		}
+	case strings.HasPrefix(block.Labels[0], "azurerm_"):
+		if link := azurermResourceLink(block); link != nil {
+			links = append(links, *link)
+		}
+	}
+	return links
+}
+
+// azapiTypeLink links the type to the ARM template reference of the resource type and api-version. The REST API
+// reference isn't used, because its pages are organized by services and operation groups which can't be derived from
+// the resource type, while the template reference has a page per resource type and api-version, it's also the page
+// which the hover of the type links to.
+func azapiTypeLink(block *hclsyntax.Block) *lang.Link {
+	typeAttribute := parser.AttributeWithName(block, "type")
+	typeValue := parser.ExtractAzureResourceType(block)
+	if typeAttribute == nil || typeValue == nil {
+		return nil
+	}
+	parts := strings.Split(*typeValue, "@")
+	if len(parts) != 2 || parts[1] == "" {
+		return nil
+	}
+	resourceType := azure.GetResourceType(parts[0])
+	index := strings.Index(resourceType, "/")
+	if index == -1 {
+		return nil
+	}
+	return &lang.Link{
+		URI: fmt.Sprintf("https://learn.microsoft.com/en-us/azure/templates/%s/%s/%s?pivots=deployment-language-terraform",
+			strings.ToLower(resourceType[0:index]), parts[1], strings.ToLower(resourceType[index+1:])),
+		Tooltip: fmt.Sprintf("View the template reference of %s", *typeValue),
+		Range:   literalRange(typeAttribute.Expr),
+	}
+}
+
+func msgraphUrlLink(block *hclsyntax.Block, data []byte) *lang.Link {
+	urlAttribute := parser.AttributeWithName(block, "url")
+	if urlAttribute == nil {
+		return nil
+	}
+	apiVersion := "v1.0"
+	if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
+		apiVersion = *v
+	}
+	urlValue := parser.ExtractMSGraphUrl(block, data)
+	resourceDef := msgraph.SchemaLoader.GetResourceDefinition(apiVersion, urlValue)
+	if resourceDef == nil || resourceDef.ExternalDocs == nil || resourceDef.ExternalDocs.Url == "" {
+		return nil
+	}
+	return &lang.Link{
+		URI:     resourceDef.ExternalDocs.Url,
+		Tooltip: fmt.Sprintf("View the documentation of %s@%s", urlValue, apiVersion),
+		Range:   literalRange(urlAttribute.Expr),
+	}
+}
+
+func azurermResourceLink(block *hclsyntax.Block) *lang.Link {
+	if block.Type != "resource" && block.Type != "data" {
+		return nil
+	}
+	resource, err := provider_schema.GetObjectInfo(block.Labels[0], block.Type == "data")
+	if err != nil || resource == nil {
+		return nil
+	}
+	return &lang.Link{
+		URI:     resource.GetResourceOrDataSourceDocLink(),
+		Tooltip: fmt.Sprintf("View the documentation of %s", block.Labels[0]),
+		Range:   labelRange(block.Labels[0], block.LabelRanges[0]),
+	}
+}
+
+// literalRange returns the range of the string literal without the quotes
+func literalRange(expr hclsyntax.Expression) hcl.Range {
+	if templateExpr, ok := expr.(*hclsyntax.TemplateExpr); ok && templateExpr.IsStringLiteral() {
+		return templateExpr.Parts[0].Range()
+	}
+	return expr.Range()
+}
+
+// labelRange returns the range of the label without the quotes
+func labelRange(label string, r hcl.Range) hcl.Range {
+	if r.End.Byte-r.Start.Byte != len(label)+2 {
+		return r
+	}
+	r.Start.Byte++
+	r.Start.Column++
+	r.End.Byte--
+	r.End.Column--
+	return r
+}
@@ -82,7 +82,7 @@ func (r *MSGraphResource) GetProperty(propertyPath string) *Property {
 	return &p
 }
 
-func msgraphBodyCandidates(data []byte, filename string, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, property *Property) []lsp.CompletionItem {
+func msgraphBodyCandidates(file *parser.File, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, property *Property) []lsp.CompletionItem {
 	if attribute.Expr != nil {
 		if _, ok := attribute.Expr.(*hclsyntax.LiteralValueExpr); ok && parser.ToLiteral(attribute.Expr) == nil {
 			if property != nil {
@@ -91,7 +91,7 @@ func msgraphBodyCandidates(data []byte, filename string, block *hclsyntax.Block,
 		}
 	}
 
-	urlValue := parser.ExtractMSGraphUrl(block, data)
+	urlValue := parser.ExtractMSGraphUrl(block, file.Src)
 	apiVersion := "v1.0"
 	if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
 		apiVersion = *v
@@ -102,13 +102,12 @@ func msgraphBodyCandidates(data []byte, filename string, block *hclsyntax.Block,
 		return nil
 	}
 
-	tokens, _ := hclsyntax.LexExpression(data[attribute.Expr.Range().Start.Byte:attribute.Expr.Range().End.Byte], filename, attribute.Expr.Range().Start)
-	hclNode := parser.BuildHclNode(tokens)
+	hclNode := file.AttributeHclNode(attribute, false)
 	if hclNode == nil {
 		return nil
 	}
 
-	return buildMSGraphBodyCandidates(hclNode, filename, pos, bodyDef)
+	return buildMSGraphBodyCandidates(hclNode, file.Filename, pos, bodyDef)
 }
 
 func buildMSGraphBodyCandidates(hclNode *parser.HclNode, filename string, pos hcl.Pos, def types.TypeBase) []lsp.CompletionItem {
@@ -163,7 +162,7 @@ func buildMSGraphBodyCandidates(hclNode *parser.HclNode, filename string, pos hc
 	return candidateList
 }
 
-func urlCandidates(_ []byte, _ string, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, _ *Property) []lsp.CompletionItem {
+func urlCandidates(_ *parser.File, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, _ *Property) []lsp.CompletionItem {
 	apiVersion := "v1.0"
 	if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
 		apiVersion = *v
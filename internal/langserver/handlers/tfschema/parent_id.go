@@ -0,0 +1,155 @@
+package tfschema
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	tenantResourceType          = "Microsoft.Resources/tenants"
+	managementGroupResourceType = "Microsoft.Management/managementGroups"
+	subscriptionResourceType    = "Microsoft.Resources/subscriptions"
+	resourceGroupResourceType   = "Microsoft.Resources/resourceGroups"
+)
+
+// ParentResourceType returns the ARM resource type which the parent_id expression refers to, or an empty string if
+// it can't be resolved. The expression can be a resource ID, an interpolated resource ID or a reference to the id of
+// an azapi or azurerm resource declared in the module. The azurermResourceType function resolves the ARM resource type
+// of the azurerm resource, its argument is like resource.azurerm_resource_group.
+func ParentResourceType(expr hclsyntax.Expression, mod *module.Module, azurermResourceType func(key string) string) string {
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateWrapExpr:
+		return ParentResourceType(e.Wrapped, mod, azurermResourceType)
+	case *hclsyntax.TemplateExpr:
+		return armResourceTypeFromID(templatePlaceholderValue(e))
+	case *hclsyntax.ScopeTraversalExpr:
+		return referenceResourceType(e.Traversal, mod, azurermResourceType)
+	}
+	return ""
+}
+
+// ScopeLabel returns the readable name of the scope which the resource type represents,
+// e.g. resource group for Microsoft.Resources/resourceGroups, other resource types are returned as they are
+func ScopeLabel(resourceType string) string {
+	switch {
+	case strings.EqualFold(resourceType, tenantResourceType):
+		return "tenant"
+	case strings.EqualFold(resourceType, managementGroupResourceType):
+		return "management group"
+	case strings.EqualFold(resourceType, subscriptionResourceType):
+		return "subscription"
+	case strings.EqualFold(resourceType, resourceGroupResourceType):
+		return "resource group"
+	}
+	return resourceType
+}
+
+// ExpectedParentScopes returns the readable names of the scopes which the resource can be deployed to,
+// child resources must be deployed to their parent resources
+func ExpectedParentScopes(resourceType string, apiVersion string) []string {
+	if parts := strings.Split(resourceType, "/"); len(parts) > 2 {
+		return []string{strings.Join(parts[0:len(parts)-1], "/")}
+	}
+
+	def, err := azure.GetResourceDefinition(resourceType, apiVersion)
+	if err != nil || def == nil {
+		return nil
+	}
+	res := make([]string, 0)
+	for _, scopeType := range def.ScopeTypes {
+		switch scopeType {
+		case types.Tenant:
+			res = append(res, "tenant")
+		case types.ManagementGroup:
+			res = append(res, "management group")
+		case types.Subscription:
+			res = append(res, "subscription")
+		case types.ResourceGroup:
+			res = append(res, "resource group")
+		case types.Extension:
+			res = append(res, "resource")
+		}
+	}
+	return res
+}
+
+func armResourceTypeFromID(id string) string {
+	if id == "" {
+		return ""
+	}
+	if id == "/" {
+		return tenantResourceType
+	}
+	resourceId, err := arm.ParseResourceID(id)
+	if err != nil {
+		return ""
+	}
+	return resourceId.ResourceType.String()
+}
+
+// templatePlaceholderValue returns the value of the template, the interpolations are replaced with placeholders,
+// so the resource type can still be parsed from IDs like /subscriptions/${var.subscription_id}/resourceGroups/example
+func templatePlaceholderValue(expr *hclsyntax.TemplateExpr) string {
+	var sb strings.Builder
+	for _, part := range expr.Parts {
+		if literal, ok := part.(*hclsyntax.LiteralValueExpr); ok {
+			if v := parser.ToLiteral(literal); v != nil {
+				sb.WriteString(*v)
+				continue
+			}
+		}
+		sb.WriteString("placeholder")
+	}
+	return sb.String()
+}
+
+func referenceResourceType(traversal hcl.Traversal, mod *module.Module, azurermResourceType func(key string) string) string {
+	ref := module.TraversalReference(traversal)
+	if ref == nil {
+		return ""
+	}
+	addressParts := strings.Split(ref.Address, ".")
+	if len(traversal) != len(addressParts)+1 {
+		return ""
+	}
+	attr, ok := traversal[len(traversal)-1].(hcl.TraverseAttr)
+	if !ok {
+		return ""
+	}
+
+	resourceKind, resourceName := "resource", addressParts[0]
+	if addressParts[0] == "data" || addressParts[0] == "ephemeral" {
+		resourceKind, resourceName = addressParts[0], addressParts[1]
+	}
+
+	switch {
+	case resourceKind == "data" && resourceName == "azapi_client_config" && attr.Name == "subscription_resource_id":
+		return subscriptionResourceType
+	case attr.Name != "id":
+		return ""
+	case strings.HasPrefix(resourceName, "azapi_"):
+		if mod == nil {
+			return ""
+		}
+		for _, declaration := range mod.DeclarationsByAddress(ref.Address) {
+			if declaration.Block == nil {
+				continue
+			}
+			if typeValue := parser.ExtractAzureResourceType(declaration.Block); typeValue != nil {
+				return strings.Split(*typeValue, "@")[0]
+			}
+		}
+	case strings.HasPrefix(resourceName, "azurerm_"):
+		if azurermResourceType != nil {
+			return azurermResourceType(resourceKind + "." + resourceName)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package tfschema_test
+
+import (
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestParentResourceType(t *testing.T) {
+	mod := module.NewModule("/tmp")
+	mod.SetFile("main.tf", []byte(`
+resource "azapi_resource" "rg" {
+  type = "Microsoft.Resources/resourceGroups@2021-04-01"
+}
+`))
+	azurermResourceType := func(key string) string {
+		if key == "resource.azurerm_virtual_network" {
+			return "Microsoft.Network/virtualNetworks"
+		}
+		return ""
+	}
+
+	testcases := []struct {
+		expr     string
+		expected string
+	}{
+		{
+			expr:     `"/"`,
+			expected: "Microsoft.Resources/tenants",
+		},
+		{
+			expr:     `"/subscriptions/00000000-0000-0000-0000-000000000000"`,
+			expected: "Microsoft.Resources/subscriptions",
+		},
+		{
+			expr:     `"/subscriptions/${var.subscription_id}/resourceGroups/example"`,
+			expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			expr:     `"/providers/Microsoft.Management/managementGroups/example"`,
+			expected: "Microsoft.Management/managementGroups",
+		},
+		{
+			expr:     `azapi_resource.rg.id`,
+			expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			expr:     `"${azapi_resource.rg.id}"`,
+			expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			expr:     `azapi_resource.rg.name`,
+			expected: "",
+		},
+		{
+			expr:     `data.azapi_client_config.current.subscription_resource_id`,
+			expected: "Microsoft.Resources/subscriptions",
+		},
+		{
+			expr:     `azurerm_virtual_network.example.id`,
+			expected: "Microsoft.Network/virtualNetworks",
+		},
+		{
+			expr:     `var.parent_id`,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(tc.expr), "main.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			if got := tfschema.ParentResourceType(expr, mod, azurermResourceType); got != tc.expected {
+				t.Errorf("ParentResourceType(%s) = %q; want %q", tc.expr, got, tc.expected)
+			}
+		})
+	}
+}
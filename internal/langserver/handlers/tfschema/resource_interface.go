@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -54,6 +55,6 @@ func (property *Property) ToHover(r hcl.Range) *lsp.Hover {
 	}
 }
 
-type GenericCandidatesFunc func(data []byte, filename string, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, property *Property) []lsp.CompletionItem
+type GenericCandidatesFunc func(file *parser.File, block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, property *Property) []lsp.CompletionItem
 type ValueCandidatesFunc func(prefix *string, r lsp.Range) []lsp.CompletionItem
-type CustomizedHoverFunc func(block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, data []byte) *lsp.Hover
+type CustomizedHoverFunc func(block *hclsyntax.Block, attribute *hclsyntax.Attribute, pos hcl.Pos, file *parser.File) *lsp.Hover
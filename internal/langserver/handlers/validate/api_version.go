@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// apiVersionValidatedBlocks are the azapi resources whose api-versions are checked against the api-version policy
+var apiVersionValidatedBlocks = map[string]bool{
+	"resource.azapi_resource":        true,
+	"resource.azapi_update_resource": true,
+}
+
+// ValidateApiVersion returns a warning if the api-version of the azapi block is a preview version which has a stable
+// replacement, or it's outdated according to the policy. The fix upgrades the api-version, and its title lists the
+// properties in body which aren't defined in the new api-version, so the breaking changes are visible before applying.
+func ValidateApiVersion(file *parser.File, block *hclsyntax.Block, policy rules.ApiVersionPolicy) *hcl.Diagnostic {
+	if block == nil || len(block.Labels) == 0 || !apiVersionValidatedBlocks[block.Type+"."+block.Labels[0]] {
+		return nil
+	}
+	if attr := parser.AttributeWithName(block, "schema_validation_enabled"); attr != nil {
+		if enabled := parser.ToLiteralBoolean(attr.Expr); enabled != nil && !*enabled {
+			return nil
+		}
+	}
+	attribute := parser.AttributeWithName(block, "type")
+	if attribute == nil {
+		return nil
+	}
+	typeValue := parser.ToLiteral(attribute.Expr)
+	if typeValue == nil {
+		return nil
+	}
+	parts := strings.Split(*typeValue, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	resourceType, apiVersion := parts[0], parts[1]
+	// the unknown api-versions are reported by ValidateAzAPIType
+	if !slices.Contains(azure.GetApiVersions(resourceType), apiVersion) {
+		return nil
+	}
+
+	newer := azure.GetNewerApiVersions(resourceType, apiVersion)
+	rule, summary, target := rules.Rule{}, "", ""
+	if policy.Preview && azure.IsPreviewApiVersion(apiVersion) {
+		stable := make([]string, 0)
+		for _, v := range newer {
+			if !azure.IsPreviewApiVersion(v) {
+				stable = append(stable, v)
+			}
+		}
+		if len(stable) != 0 {
+			target = azure.LatestApiVersion(stable)
+			rule = rules.PreviewApiVersion
+			summary = ErrorPreviewApiVersion(apiVersion, target)
+		}
+	}
+	if summary == "" && policy.MaxNewerVersions > 0 && len(newer) >= policy.MaxNewerVersions {
+		target = azure.LatestApiVersion(newer)
+		rule = rules.OutdatedApiVersion
+		summary = ErrorOutdatedApiVersion(apiVersion, len(newer), target)
+	}
+	if summary == "" {
+		return nil
+	}
+
+	diag := newDiagnostic(rule, summary, attribute.Expr.Range())
+	return withFix(diag, upgradeApiVersionFix(file, block, attribute, resourceType, apiVersion, target))
+}
+
+// upgradeApiVersionFix replaces the api-version in the `type` attribute, the properties which are valid in the current
+// api-version but not defined in the target one are listed in the title
+func upgradeApiVersionFix(file *parser.File, block *hclsyntax.Block, attribute *hclsyntax.Attribute, resourceType, apiVersion, target string) *Fix {
+	title := fmt.Sprintf("Upgrade api-version to `%s`", target)
+	if removed := removedProperties(file, block, resourceType, apiVersion, target); len(removed) != 0 {
+		title = fmt.Sprintf("%s, %s not defined in the new api-version", title, joinKeys(removed))
+	}
+	return &Fix{
+		Title:   title,
+		Range:   attribute.Expr.Range(),
+		NewText: fmt.Sprintf(`"%s@%s"`, resourceType, target),
+	}
+}
+
+// removedProperties validates the body against both api-versions and returns the names of the properties which are
+// only reported as not expected with the target api-version
+func removedProperties(file *parser.File, block *hclsyntax.Block, resourceType, apiVersion, target string) []string {
+	current, err := azure.GetResourceDefinition(resourceType, apiVersion)
+	if err != nil || current == nil {
+		return nil
+	}
+	upgraded, err := azure.GetResourceDefinition(resourceType, target)
+	if err != nil || upgraded == nil {
+		return nil
+	}
+
+	reported := make(map[int]bool)
+	for _, diag := range validateAzAPIBody(file, block, current) {
+		if diag.Subject != nil {
+			reported[diag.Subject.Start.Byte] = true
+		}
+	}
+	res := make([]string, 0)
+	for _, diag := range validateAzAPIBody(file, block, upgraded) {
+		r := diag.Subject
+		if r == nil || reported[r.Start.Byte] || !hasRule(diag, rules.UnknownProperty) {
+			continue
+		}
+		if r.Start.Byte >= 0 && r.Start.Byte < r.End.Byte && r.End.Byte <= len(file.Src) {
+			res = append(res, strings.Trim(string(file.Src[r.Start.Byte:r.End.Byte]), `"`))
+		}
+	}
+	return res
+}
@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ValidateAzAPIType checks the resource type and api-version in the `type` attribute of the azapi block, it returns
+// a diagnostic with a fix which applies the closest resource type or api-version if either of them is not found.
+// The data plane resources are skipped, because their types are not defined in the azure schema.
+func ValidateAzAPIType(block *hclsyntax.Block) *hcl.Diagnostic {
+	if block == nil || len(block.Labels) == 0 || block.Labels[0] == "azapi_data_plane_resource" {
+		return nil
+	}
+	if attr := parser.AttributeWithName(block, "schema_validation_enabled"); attr != nil {
+		if enabled := parser.ToLiteralBoolean(attr.Expr); enabled != nil && !*enabled {
+			return nil
+		}
+	}
+	attribute := parser.AttributeWithName(block, "type")
+	if attribute == nil {
+		return nil
+	}
+	typeValue := parser.ToLiteral(attribute.Expr)
+	if typeValue == nil {
+		return nil
+	}
+	parts := strings.Split(*typeValue, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	azureSchema := azure.GetAzureSchema()
+	if azureSchema == nil {
+		return nil
+	}
+
+	// the value of the node is quoted like the properties in body, so the fix can replace the string literal
+	quoted := `"` + *typeValue + `"`
+	hclNode := &parser.HclNode{
+		Key:        "type",
+		KeyRange:   attribute.NameRange,
+		Value:      &quoted,
+		ValueRange: attribute.Expr.Range(),
+	}
+
+	resourceType, apiVersion := parts[0], parts[1]
+	if azure.GetResourceType(resourceType) == "" {
+		options := make([]string, 0, len(azureSchema.Resources))
+		for key := range azureSchema.Resources {
+			options = append(options, key)
+		}
+		suggestion := suggestResourceType(resourceType, options)
+		if suggestion == "" {
+			return nil
+		}
+		suggestedVersion := apiVersion
+		if apiVersions := azure.GetApiVersions(suggestion); !slices.Contains(apiVersions, apiVersion) {
+			suggestedVersion = azure.LatestApiVersion(apiVersions)
+		}
+		return withFix(newDiagnostic(rules.UnknownResourceType, ErrorUnknownResourceType(resourceType, suggestion), attribute.Expr.Range()), replaceValueFix(hclNode, []string{suggestion + "@" + suggestedVersion}))
+	}
+
+	apiVersions := azure.GetApiVersions(resourceType)
+	if len(apiVersions) == 0 || slices.Contains(apiVersions, apiVersion) {
+		return nil
+	}
+	suggestion := getSuggestion(apiVersion, apiVersions)
+	return withFix(newDiagnostic(rules.UnknownApiVersion, ErrorUnknownApiVersion(resourceType, apiVersion, apiVersions), attribute.Expr.Range()), replaceValueFix(hclNode, []string{resourceType + "@" + suggestion}))
+}
+
+// suggestResourceType returns the closest resource type, the resource types are compared ignoring case,
+// and the types under the same provider namespace are preferred
+func suggestResourceType(resourceType string, options []string) string {
+	namespace := strings.ToLower(strings.Split(resourceType, "/")[0])
+	candidates := make([]string, 0)
+	for _, option := range options {
+		if strings.ToLower(strings.Split(option, "/")[0]) == namespace {
+			candidates = append(candidates, option)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = options
+	}
+
+	suggestion := ""
+	distance := 1 << 16
+	for _, option := range candidates {
+		if dist := editDistance(strings.ToLower(resourceType), strings.ToLower(option)); dist < distance || (dist == distance && option < suggestion) {
+			distance = dist
+			suggestion = option
+		}
+	}
+	return suggestion
+}
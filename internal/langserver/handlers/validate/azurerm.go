@@ -0,0 +1,235 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	provider_schema "github.com/Azure/ms-terraform-lsp/provider-schema"
+	"github.com/Azure/ms-terraform-lsp/provider-schema/azurerm/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// metaArguments are the arguments and blocks which are handled by terraform instead of the provider
+var metaArguments = map[string]bool{
+	"count":       true,
+	"for_each":    true,
+	"provider":    true,
+	"depends_on":  true,
+	"lifecycle":   true,
+	"connection":  true,
+	"provisioner": true,
+	"timeouts":    true,
+}
+
+// ValidateAzureRMBlock validates the attributes and nested blocks of the azurerm resource or data source against the
+// provider schema. The block is skipped if the resource or data source is not found in the schema.
+func ValidateAzureRMBlock(src []byte, block *hclsyntax.Block) hcl.Diagnostics {
+	if block == nil || len(block.Labels) == 0 || !strings.HasPrefix(block.Labels[0], schema.AzureRMPrefix) {
+		return nil
+	}
+	obj := provider_schema.GetFinalTerraformObject(block.Labels[0], block.Type == "data")
+	if obj == nil || len(obj.Fields) == 0 {
+		return nil
+	}
+	v := &azurermValidator{
+		src:          src,
+		root:         block,
+		objName:      block.Labels[0],
+		isDataSource: block.Type == "data",
+	}
+	return v.validateBody(block, "", obj.Fields)
+}
+
+type azurermValidator struct {
+	src          []byte
+	root         *hclsyntax.Block
+	objName      string
+	isDataSource bool
+}
+
+// validateBody validates the body of the block against the fields, path is the attribute path of the block,
+// it's empty for the resource or data source itself. The fields of nested blocks are retrieved by
+// provider_schema.NavigateToNestedBlock, nested blocks without documented fields are not validated.
+func (v *azurermValidator) validateBody(block *hclsyntax.Block, path string, fields map[string]*schema.SchemaAttribute) hcl.Diagnostics {
+	topLevel := path == ""
+	diags := make([]*hcl.Diagnostic, 0)
+	options := make([]string, 0)
+	for key, field := range fields {
+		if field.Computed && !field.Optional && !field.Required {
+			continue
+		}
+		options = append(options, key)
+	}
+	sort.Strings(options)
+
+	// defined maps the names of the attributes and nested blocks to their name ranges
+	defined := make(map[string]hcl.Range)
+	attributes := make([]*hclsyntax.Attribute, 0, len(block.Body.Attributes))
+	for _, attribute := range block.Body.Attributes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].SrcRange.Start.Byte < attributes[j].SrcRange.Start.Byte
+	})
+	for _, attribute := range attributes {
+		name := attribute.Name
+		defined[name] = attribute.NameRange
+		field, ok := fields[name]
+		if !ok {
+			if topLevel && metaArguments[name] {
+				continue
+			}
+			diags = append(diags, unknownAzureRMDiagnostic(name, attribute.NameRange, options))
+			continue
+		}
+		if diag := validateAzureRMPossibleValues(v.src, attribute, field); diag != nil {
+			diags = append(diags, diag)
+		}
+	}
+
+	for _, nestedBlock := range block.Body.Blocks {
+		name, nameRange, content := azurermNestedBlock(nestedBlock)
+		if name == "" {
+			continue
+		}
+		if _, ok := defined[name]; !ok {
+			defined[name] = nameRange
+		}
+		field, ok := fields[name]
+		if !ok {
+			if topLevel && metaArguments[name] {
+				continue
+			}
+			diags = append(diags, unknownAzureRMDiagnostic(name, nameRange, options))
+			continue
+		}
+		if content == nil || field.NestingMode == 0 {
+			continue
+		}
+		nestedPath := name
+		if !topLevel {
+			nestedPath = path + "." + name
+		}
+		nested, err := provider_schema.NavigateToNestedBlock(v.objName, nestedPath, v.isDataSource)
+		if err != nil || len(nested.Fields) == 0 {
+			continue
+		}
+		diags = append(diags, v.validateBody(content, nestedPath, nested.Fields)...)
+	}
+
+	missing := make([]string, 0)
+	for key, field := range fields {
+		if _, ok := defined[key]; field.Required && !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		diags = append(diags, withFix(newDiagnostic(rules.MissingRequiredProperty, ErrorShouldDefine(key), block.DefRange()), insertAttributeFix(block, fields[key])))
+	}
+
+	diags = append(diags, v.validateConstraints(block, path, fields, defined)...)
+	return diags
+}
+
+// azurermNestedBlock returns the name, the name range and the content of the nested block,
+// the content of a dynamic block is its content block, which may be nil while typing
+func azurermNestedBlock(block *hclsyntax.Block) (string, hcl.Range, *hclsyntax.Block) {
+	if block.Type != "dynamic" {
+		return block.Type, block.TypeRange, block
+	}
+	if len(block.Labels) == 0 {
+		return "", hcl.Range{}, nil
+	}
+	var content *hclsyntax.Block
+	for _, b := range block.Body.Blocks {
+		if b.Type == "content" {
+			content = b
+		}
+	}
+	return block.Labels[0], block.LabelRanges[0], content
+}
+
+func unknownAzureRMDiagnostic(name string, r hcl.Range, options []string) *hcl.Diagnostic {
+	hclNode := &parser.HclNode{
+		Key:      name,
+		KeyRange: r,
+	}
+	return withFix(newDiagnostic(rules.UnknownProperty, ErrorShouldNotDefine(name, options), r), renamePropertyFix(hclNode, getSuggestion(name, options)))
+}
+
+// validateAzureRMPossibleValues checks the string literal value of the attribute against the documented possible values
+func validateAzureRMPossibleValues(src []byte, attribute *hclsyntax.Attribute, field *schema.SchemaAttribute) *hcl.Diagnostic {
+	if field.AttributeType != cty.String || len(field.PossibleValues) == 0 {
+		return nil
+	}
+	value := parser.ToLiteral(attribute.Expr)
+	if value == nil {
+		return nil
+	}
+	for _, option := range field.PossibleValues {
+		if option == *value {
+			return nil
+		}
+	}
+	r := attribute.Expr.Range()
+	raw := ""
+	if r.Start.Byte >= 0 && r.End.Byte <= len(src) && r.Start.Byte <= r.End.Byte {
+		raw = string(src[r.Start.Byte:r.End.Byte])
+	}
+	hclNode := &parser.HclNode{
+		Key:        attribute.Name,
+		KeyRange:   attribute.NameRange,
+		Value:      &raw,
+		ValueRange: r,
+	}
+	options := make([]string, len(field.PossibleValues))
+	copy(options, field.PossibleValues)
+	sort.Strings(options)
+	return withFix(newDiagnostic(rules.InvalidValue, ErrorNotMatchAnyValues(attribute.Name, *value, options), r), replaceValueFix(hclNode, options))
+}
+
+// insertAttributeFix inserts the missing attribute or block with a placeholder value after the open brace of the block
+func insertAttributeFix(block *hclsyntax.Block, field *schema.SchemaAttribute) *Fix {
+	if field == nil {
+		return nil
+	}
+	property := fmt.Sprintf("%s = %s", field.Name, azurermPlaceholderValue(field.AttributeType))
+	if field.NestingMode != 0 {
+		property = fmt.Sprintf("%s {}", field.Name)
+	}
+
+	indent := block.TypeRange.Start.Column - 1
+	pos := block.OpenBraceRange.End
+	newText := "\n" + strings.Repeat(" ", indent+2) + property
+	if block.OpenBraceRange.End.Line == block.CloseBraceRange.Start.Line {
+		newText += "\n" + strings.Repeat(" ", indent)
+	}
+	return &Fix{
+		Title:   fmt.Sprintf("Add required property `%s`", field.Name),
+		Range:   hcl.Range{Filename: block.OpenBraceRange.Filename, Start: pos, End: pos},
+		NewText: newText,
+	}
+}
+
+// azurermPlaceholderValue returns a value which matches the attribute type
+func azurermPlaceholderValue(t cty.Type) string {
+	switch {
+	case t == cty.String:
+		return `""`
+	case t == cty.Number:
+		return "0"
+	case t == cty.Bool:
+		return "false"
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		return "[]"
+	case t.IsMapType() || t.IsObjectType():
+		return "{}"
+	}
+	return "null"
+}
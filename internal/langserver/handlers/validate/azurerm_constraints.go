@@ -0,0 +1,154 @@
+package validate
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/Azure/ms-terraform-lsp/provider-schema/azurerm/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// validateConstraints checks the ConflictsWith, ExactlyOneOf, AtLeastOneOf and RequiredWith constraints of the fields,
+// the diagnostics point at the offending attributes, or at the block if none of the attributes in a group is defined.
+// The keys in the constraints are paths from the resource, like `network_rules.0.default_action`.
+func (v *azurermValidator) validateConstraints(block *hclsyntax.Block, path string, fields map[string]*schema.SchemaAttribute, defined map[string]hcl.Range) hcl.Diagnostics {
+	diags := make([]*hcl.Diagnostic, 0)
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	reportedGroups := make(map[string]bool)
+	for _, key := range keys {
+		field := fields[key]
+		r, ok := defined[key]
+		if !ok {
+			groups := []struct {
+				keys    []string
+				summary func(keys []string) string
+			}{
+				{keys: field.ExactlyOneOf, summary: ErrorExactlyOneOfMissing},
+				{keys: field.AtLeastOneOf, summary: ErrorAtLeastOneOf},
+			}
+			for _, group := range groups {
+				if len(group.keys) == 0 {
+					continue
+				}
+				// the fields in the same group share the constraint, it's only reported once
+				summary := group.summary(v.displayKeys(group.keys, path))
+				if reportedGroups[summary] {
+					continue
+				}
+				reportedGroups[summary] = true
+				if v.countDefined(group.keys, path, defined) == 0 {
+					diags = append(diags, newDiagnostic(rules.PropertyConstraint, summary, block.DefRange()))
+				}
+			}
+			continue
+		}
+
+		for _, conflict := range field.ConflictsWith {
+			if v.isDefined(conflict, path, defined) {
+				diags = append(diags, newDiagnostic(rules.PropertyConstraint, ErrorConflictsWith(key, v.displayKey(conflict, path)), r))
+			}
+		}
+
+		if len(field.ExactlyOneOf) != 0 && v.countDefined(field.ExactlyOneOf, path, defined) > 1 {
+			diags = append(diags, newDiagnostic(rules.PropertyConstraint, ErrorExactlyOneOf(v.displayKeys(field.ExactlyOneOf, path)), r))
+		}
+
+		missing := make([]string, 0)
+		for _, required := range field.RequiredWith {
+			if !v.isDefined(required, path, defined) {
+				missing = append(missing, v.displayKey(required, path))
+			}
+		}
+		if len(missing) != 0 {
+			diags = append(diags, newDiagnostic(rules.PropertyConstraint, ErrorRequiredWith(key, missing), r))
+		}
+	}
+	return diags
+}
+
+func (v *azurermValidator) countDefined(keys []string, path string, defined map[string]hcl.Range) int {
+	count := 0
+	for _, key := range keys {
+		if v.isDefined(key, path, defined) {
+			count++
+		}
+	}
+	return count
+}
+
+// isDefined returns whether the attribute or block is defined, the keys of the siblings are looked up in the defined
+// names of the current block, other keys are looked up from the resource by using the first block of each level
+func (v *azurermValidator) isDefined(key string, path string, defined map[string]hcl.Range) bool {
+	parts := normalizedPath(key)
+	if len(parts) == 0 {
+		return false
+	}
+	if strings.Join(parts[:len(parts)-1], ".") == path {
+		_, ok := defined[parts[len(parts)-1]]
+		return ok
+	}
+
+	body := v.root.Body
+	for i, part := range parts {
+		last := i == len(parts)-1
+		if _, ok := body.Attributes[part]; ok && last {
+			return true
+		}
+		var next *hclsyntax.Block
+		for _, nestedBlock := range body.Blocks {
+			if name, _, content := azurermNestedBlock(nestedBlock); name == part {
+				if last {
+					return true
+				}
+				next = content
+				break
+			}
+		}
+		if next == nil {
+			return false
+		}
+		body = next.Body
+	}
+	return false
+}
+
+// displayKey returns the name of the sibling attribute, or the path without indexes for other attributes
+func (v *azurermValidator) displayKey(key string, path string) string {
+	parts := normalizedPath(key)
+	if len(parts) == 0 {
+		return key
+	}
+	if strings.Join(parts[:len(parts)-1], ".") == path {
+		return parts[len(parts)-1]
+	}
+	return strings.Join(parts, ".")
+}
+
+func (v *azurermValidator) displayKeys(keys []string, path string) []string {
+	res := make([]string, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, v.displayKey(key, path))
+	}
+	return res
+}
+
+// normalizedPath splits the key into parts and removes the list indexes, e.g. `a.0.b` is split into [a, b]
+func normalizedPath(key string) []string {
+	res := make([]string, 0)
+	for _, part := range strings.Split(key, ".") {
+		if _, err := strconv.Atoi(part); err == nil || part == "" {
+			continue
+		}
+		res = append(res, part)
+	}
+	return res
+}
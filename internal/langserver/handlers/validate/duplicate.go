@@ -0,0 +1,28 @@
+package validate
+
+import (
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// ValidateDuplicateDeclarations returns an error for each declaration in the file whose address is already declared
+// in the module, the declarations are ordered by the file names and their positions, like terraform does.
+func ValidateDuplicateDeclarations(mod *module.Module, filename string) hcl.Diagnostics {
+	if mod == nil {
+		return nil
+	}
+	declared := make(map[string]hcl.Range)
+	diags := make([]*hcl.Diagnostic, 0)
+	for _, declaration := range mod.Declarations() {
+		first, ok := declared[declaration.Address]
+		if !ok {
+			declared[declaration.Address] = declaration.DefRange
+			continue
+		}
+		if declaration.DefRange.Filename == filename {
+			diags = append(diags, newDiagnostic(rules.DuplicateDeclaration, ErrorDuplicateDeclaration(declaration.Address, first), declaration.DefRange))
+		}
+	}
+	return diags
+}
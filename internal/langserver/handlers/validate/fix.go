@@ -0,0 +1,250 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Fix is a text edit which resolves a diagnostic, it's attached to the Extra field of the diagnostic
+type Fix struct {
+	// Title is the title of the quick fix shown to the user
+	Title string
+	// Range is the range which is replaced by the NewText, the text is inserted when the range is empty
+	Range hcl.Range
+	// NewText is the replacement, the range is deleted when it's empty
+	NewText string
+	// Unambiguous is true if the fix is the only clear answer, e.g. removing a read only property,
+	// these fixes are applied by the source.fixAll code action
+	Unambiguous bool
+	// AdditionalEdits are the other edits of the fix, they must not overlap with the Range.
+	// They're always applied together with the Range, including by the source.fixAll code action.
+	AdditionalEdits []Edit
+}
+
+// Edit is a text edit which is applied with the fix, e.g. inserting the removed property at another place
+type Edit struct {
+	Range   hcl.Range
+	NewText string
+}
+
+// DiagnosticFix returns the fix attached to the diagnostic, or nil if the diagnostic can't be fixed automatically
+func DiagnosticFix(diag *hcl.Diagnostic) *Fix {
+	fix, ok := hcl.DiagnosticExtra[*Fix](diag)
+	if !ok {
+		return nil
+	}
+	return fix
+}
+
+func withFix(diag *hcl.Diagnostic, fix *Fix) *hcl.Diagnostic {
+	if fix == nil {
+		return diag
+	}
+	if extra, ok := diag.Extra.(*diagnosticExtra); ok {
+		extra.fix = fix
+	} else {
+		diag.Extra = fix
+	}
+	return diag
+}
+
+// renamePropertyFix replaces the key of the property with the suggested one
+func renamePropertyFix(hclNode *parser.HclNode, suggestion string) *Fix {
+	suggestion = strings.TrimPrefix(suggestion, ".")
+	if suggestion == "" || hclNode.KeyRange.Empty() {
+		return nil
+	}
+	return &Fix{
+		Title:   fmt.Sprintf("Replace `%s` with `%s`", hclNode.Key, suggestion),
+		Range:   hclNode.KeyRange,
+		NewText: suggestion,
+	}
+}
+
+// replaceValueFix replaces the string literal value of the property with the suggested one,
+// the fix is unambiguous if the value only differs from one of the options in case
+func replaceValueFix(hclNode *parser.HclNode, options []string) *Fix {
+	if hclNode.Value == nil {
+		return nil
+	}
+	value := strings.TrimSpace(*hclNode.Value)
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) || strings.Contains(value, "${") {
+		return nil
+	}
+	value = strings.Trim(value, `"`)
+
+	matches := make([]string, 0)
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			matches = append(matches, option)
+		}
+	}
+	unambiguous := len(matches) == 1
+	suggestion := getSuggestion(value, options)
+	if unambiguous {
+		suggestion = matches[0]
+	}
+	if suggestion == "" {
+		return nil
+	}
+	return &Fix{
+		Title:       fmt.Sprintf("Replace `%s` with `%s`", value, suggestion),
+		Range:       hclNode.ValueRange,
+		NewText:     fmt.Sprintf(`"%s"`, suggestion),
+		Unambiguous: unambiguous,
+	}
+}
+
+// literalValueFix replaces the value of the string literal property with the only accepted value
+func literalValueFix(hclNode *parser.HclNode, expected string) *Fix {
+	fix := replaceValueFix(hclNode, []string{expected})
+	if fix != nil {
+		fix.Unambiguous = true
+	}
+	return fix
+}
+
+// removePropertyFix removes the property from the parent object, the range is expanded to the whole lines
+// by expandDeletionRange when the source is available
+func removePropertyFix(parent *parser.HclNode, hclNode *parser.HclNode) *Fix {
+	if hclNode.KeyRange.Empty() {
+		return nil
+	}
+	r := hclNode.GetRange()
+	if parent.KeyValueFormat != parser.KeyEqualValue {
+		// the key range doesn't include the quotes
+		r.Start.Byte--
+		r.Start.Column--
+	}
+	return &Fix{
+		Title:       fmt.Sprintf("Remove read only property `%s`", hclNode.Key),
+		Range:       r,
+		Unambiguous: true,
+	}
+}
+
+// insertPropertyFix inserts the missing property with the placeholder value into the object,
+// it's inserted before the first property, or after the open brace if the object is empty
+func insertPropertyFix(hclNode *parser.HclNode, key string, value string) *Fix {
+	if hclNode.ValueRange.Empty() || hclNode.Value != nil {
+		return nil
+	}
+
+	property := fmt.Sprintf("%s = %s", key, value)
+	switch hclNode.KeyValueFormat {
+	case parser.QuotedKeyEqualValue:
+		property = fmt.Sprintf(`"%s" = %s`, key, value)
+	case parser.QuotedKeyColonValue:
+		property = fmt.Sprintf(`"%s": %s`, key, value)
+	}
+
+	var first *parser.HclNode
+	for _, child := range hclNode.Children {
+		// the name attribute is added to the body, but it's defined outside the object
+		if child.KeyRange.Empty() || !parser.ContainsPos(hclNode.ValueRange, child.KeyRange.Start) {
+			continue
+		}
+		if first == nil || child.KeyRange.Start.Byte < first.KeyRange.Start.Byte {
+			first = child
+		}
+	}
+
+	fix := &Fix{
+		Title: fmt.Sprintf("Add required property `%s`", key),
+	}
+	if first != nil {
+		pos := first.KeyRange.Start
+		if hclNode.KeyValueFormat != parser.KeyEqualValue {
+			pos.Byte--
+			pos.Column--
+		}
+		separator := "\n"
+		if hclNode.KeyValueFormat == parser.QuotedKeyColonValue {
+			separator = ",\n"
+		}
+		fix.Range = hcl.Range{Filename: first.KeyRange.Filename, Start: pos, End: pos}
+		fix.NewText = property + separator + strings.Repeat(" ", pos.Column-1)
+		return fix
+	}
+
+	indent := 0
+	if !hclNode.KeyRange.Empty() {
+		indent = hclNode.KeyRange.Start.Column - 1
+	}
+	pos := hclNode.ValueRange.Start
+	pos.Byte++
+	pos.Column++
+	fix.Range = hcl.Range{Filename: hclNode.ValueRange.Filename, Start: pos, End: pos}
+	fix.NewText = "\n" + strings.Repeat(" ", indent+2) + property + "\n" + strings.Repeat(" ", indent)
+	return fix
+}
+
+// placeholderValue returns a value which matches the type, the unknown types, e.g. discriminators, are strings
+func placeholderValue(typeBase *types.TypeBase) string {
+	if typeBase == nil || *typeBase == nil {
+		return `""`
+	}
+	switch t := (*typeBase).(type) {
+	case *types.StringLiteralType:
+		return fmt.Sprintf(`"%s"`, t.Value)
+	case *types.StringType:
+		return `""`
+	case *types.IntegerType:
+		return "0"
+	case *types.BooleanType:
+		return "false"
+	case *types.ArrayType:
+		return "[]"
+	case *types.ObjectType, *types.DiscriminatedObjectType:
+		return "{}"
+	case *types.UnionType:
+		for _, element := range t.Elements {
+			if element != nil && element.Type != nil {
+				return placeholderValue(element.Type)
+			}
+		}
+	}
+	return "null"
+}
+
+// expandDeletionRange expands the range to the whole lines if there's nothing else on the lines,
+// so removing a property doesn't leave an empty line
+func expandDeletionRange(src []byte, r hcl.Range) hcl.Range {
+	if r.Start.Byte < 0 || r.End.Byte > len(src) || r.Start.Byte > r.End.Byte {
+		return r
+	}
+	start := r.Start.Byte
+	for start > 0 && (src[start-1] == ' ' || src[start-1] == '\t') {
+		start--
+	}
+	if start > 0 && src[start-1] != '\n' {
+		return r
+	}
+	end := r.End.Byte
+	for end < len(src) && (src[end] == ' ' || src[end] == '\t' || src[end] == ',') {
+		end++
+	}
+	if end < len(src) && src[end] == '\r' {
+		end++
+	}
+	if end == len(src) {
+		return hcl.Range{
+			Filename: r.Filename,
+			Start:    hcl.Pos{Line: r.Start.Line, Column: 1, Byte: start},
+			End:      hcl.Pos{Line: r.End.Line, Column: r.End.Column + end - r.End.Byte, Byte: end},
+		}
+	}
+	if src[end] != '\n' {
+		return r
+	}
+	end++
+	return hcl.Range{
+		Filename: r.Filename,
+		Start:    hcl.Pos{Line: r.Start.Line, Column: 1, Byte: start},
+		End:      hcl.Pos{Line: r.End.Line + 1, Column: 1, Byte: end},
+	}
+}
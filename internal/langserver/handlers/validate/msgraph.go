@@ -0,0 +1,238 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/msgraph"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	msgraphtypes "github.com/ms-henglu/go-msgraph-types/types"
+)
+
+// validatedMSGraphBlocks are the msgraph resources whose body is validated, the keys are like resource.msgraph_resource
+var validatedMSGraphBlocks = map[string]bool{
+	"resource.msgraph_resource":        true,
+	"resource.msgraph_update_resource": true,
+}
+
+// ValidateMSGraphBlock validates the body of the msgraph resource against the schema of the url and api_version.
+// The url of msgraph_update_resource refers to the resource itself, so its body is validated against the schema of
+// the collection, and the required properties are not checked.
+func ValidateMSGraphBlock(file *parser.File, block *hclsyntax.Block) hcl.Diagnostics {
+	if block == nil || len(block.Labels) == 0 {
+		return nil
+	}
+
+	apiVersion := "v1.0"
+	if v := parser.BlockAttributeLiteralValue(block, "api_version"); v != nil {
+		apiVersion = *v
+	}
+	url := parser.ExtractMSGraphUrl(block, file.Src)
+	isUpdate := block.Labels[0] == "msgraph_update_resource"
+	if isUpdate {
+		url = url[:max(strings.LastIndex(url, "/"), 0)]
+	}
+	if url == "" {
+		return nil
+	}
+	bodyDef := msgraph.SchemaLoader.GetResourceDefinition(apiVersion, url)
+	if bodyDef == nil {
+		return nil
+	}
+
+	attribute, hclNode := bodyHclNode(file, block)
+	if attribute == nil || hclNode == nil {
+		return nil
+	}
+	dummy, ok := hclNode.Children["dummy"]
+	if !ok {
+		return nil
+	}
+	dummy = keyedCopy(dummy, attribute.NameRange)
+	diags := ValidateMSGraph(dummy, bodyDef.AsTypeBase())
+	if !isUpdate {
+		return diags
+	}
+	res := hcl.Diagnostics{}
+	for _, diag := range diags {
+		if !hasRule(diag, rules.MissingRequiredProperty) {
+			res = append(res, diag)
+		}
+	}
+	return res
+}
+
+// ValidateMSGraph validates the hcl node against the msgraph type, it works the same way as Validate does for azapi
+func ValidateMSGraph(hclNode *parser.HclNode, typeBase *msgraphtypes.TypeBase) hcl.Diagnostics {
+	if typeBase == nil || *typeBase == nil || hclNode == nil {
+		return nil
+	}
+	diags := make([]*hcl.Diagnostic, 0)
+	switch t := (*typeBase).(type) {
+	case *msgraphtypes.ArrayType:
+		if !hclNode.IsValueArray() || t.ItemType == nil {
+			break
+		}
+		for _, child := range hclNode.Children {
+			diags = append(diags, ValidateMSGraph(child, &t.ItemType.Type)...)
+		}
+	case *msgraphtypes.ObjectType:
+		if !hclNode.IsValueMap() {
+			break
+		}
+		// check properties defined in body, but not in schema, the keys are sorted so the diagnostics are stable
+		children := make([]string, 0, len(hclNode.Children))
+		for key := range hclNode.Children {
+			children = append(children, key)
+		}
+		sort.Strings(children)
+		for _, key := range children {
+			value := hclNode.Children[key]
+			// the annotations, e.g. `owners@odata.bind`, are not defined in the schema
+			if strings.Contains(key, "@odata.") {
+				continue
+			}
+			if def, ok := t.Properties[key]; ok {
+				if def.IsReadOnly() {
+					diags = append(diags, withFix(newDiagnostic(rules.ReadOnlyProperty, ErrorShouldNotDefineReadOnly(key), value.KeyRange), removePropertyFix(hclNode, value)))
+					continue
+				}
+				if def.Type != nil {
+					diags = append(diags, ValidateMSGraph(value, &def.Type.Type)...)
+				}
+				continue
+			}
+			if t.AdditionalProperties != nil {
+				diags = append(diags, ValidateMSGraph(value, &t.AdditionalProperties.Type)...)
+			} else {
+				options := make([]string, 0)
+				for key := range t.Properties {
+					options = append(options, key)
+				}
+				sort.Strings(options)
+				diags = append(diags, withFix(newDiagnostic(rules.UnknownProperty, ErrorShouldNotDefine(key, options), value.KeyRange), renamePropertyFix(value, getSuggestion(key, options))))
+			}
+		}
+
+		// check properties required in schema, but not in body
+		keys := make([]string, 0)
+		for key, value := range t.Properties {
+			if value.IsRequired() && hclNode.Children[key] == nil && !strings.Contains(key, "@odata.") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := t.Properties[key]
+			diags = append(diags, withFix(newDiagnostic(rules.MissingRequiredProperty, ErrorShouldDefine(key), hclNode.KeyRange), insertPropertyFix(hclNode, key, msgraphPlaceholderValue(msgraphPropertyType(value)))))
+		}
+	case *msgraphtypes.ResourceType:
+		if t.Body != nil {
+			return ValidateMSGraph(hclNode, &t.Body.Type)
+		}
+	case *msgraphtypes.AnyType:
+	case *msgraphtypes.BooleanType:
+	case *msgraphtypes.NumberType:
+		if hclNode.Value == nil {
+			break
+		}
+		if value, err := strconv.Atoi(strings.TrimSpace(*hclNode.Value)); err == nil {
+			for _, err := range t.Validate(value, hclNode.Key) {
+				diags = append(diags, newDiagnostic(rules.ValueConstraint, err.Error(), hclNode.ValueRange))
+			}
+		}
+	case *msgraphtypes.StringType:
+		value, ok := stringLiteralValue(hclNode)
+		if !ok {
+			break
+		}
+		if len(t.Enum) != 0 {
+			found := false
+			for _, option := range t.Enum {
+				if option == value {
+					found = true
+					break
+				}
+			}
+			if !found {
+				options := make([]string, len(t.Enum))
+				copy(options, t.Enum)
+				sort.Strings(options)
+				diags = append(diags, withFix(newDiagnostic(rules.InvalidValue, ErrorNotMatchAnyValues(hclNode.Key, value, options), hclNode.ValueRange), replaceValueFix(hclNode, options)))
+				break
+			}
+		}
+		for _, err := range t.Validate(value, hclNode.Key) {
+			diags = append(diags, newDiagnostic(rules.ValueConstraint, err.Error(), hclNode.ValueRange))
+		}
+	case *msgraphtypes.UnionType:
+		elements := msgraphUnionElements(t)
+		// most of the properties are unions of the type and null, so they're validated as the type
+		if len(elements) == 1 {
+			return ValidateMSGraph(hclNode, elements[0])
+		}
+		for _, element := range elements {
+			if len(ValidateMSGraph(hclNode, element)) == 0 {
+				return diags
+			}
+		}
+		if len(elements) != 0 {
+			diags = append(diags, newDiagnostic(rules.InvalidValue, ErrorNotMatchAny(hclNode.Key), hclNode.GetRange()))
+		}
+	}
+	return diags
+}
+
+// msgraphUnionElements returns the elements of the union, the empty objects which represent null are skipped
+func msgraphUnionElements(t *msgraphtypes.UnionType) []*msgraphtypes.TypeBase {
+	res := make([]*msgraphtypes.TypeBase, 0)
+	for _, element := range t.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		if objectType, ok := element.Type.(*msgraphtypes.ObjectType); ok && len(objectType.Properties) == 0 && objectType.AdditionalProperties == nil {
+			continue
+		}
+		res = append(res, &element.Type)
+	}
+	return res
+}
+
+func msgraphPropertyType(property msgraphtypes.ObjectProperty) *msgraphtypes.TypeBase {
+	if property.Type == nil {
+		return nil
+	}
+	return &property.Type.Type
+}
+
+// msgraphPlaceholderValue returns a value which matches the msgraph type
+func msgraphPlaceholderValue(typeBase *msgraphtypes.TypeBase) string {
+	if typeBase == nil || *typeBase == nil {
+		return `""`
+	}
+	switch t := (*typeBase).(type) {
+	case *msgraphtypes.StringType:
+		if len(t.Enum) != 0 {
+			return fmt.Sprintf(`"%s"`, t.Enum[0])
+		}
+		return `""`
+	case *msgraphtypes.NumberType:
+		return "0"
+	case *msgraphtypes.BooleanType:
+		return "false"
+	case *msgraphtypes.ArrayType:
+		return "[]"
+	case *msgraphtypes.ObjectType:
+		return "{}"
+	case *msgraphtypes.UnionType:
+		if elements := msgraphUnionElements(t); len(elements) != 0 {
+			return msgraphPlaceholderValue(elements[0])
+		}
+	}
+	return "null"
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure"
+	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ParentTypeResolver returns the ARM resource type which the parent_id expression refers to,
+// or an empty string if it can't be resolved
+type ParentTypeResolver func(expr hclsyntax.Expression) string
+
+// parentScopeValidatedBlocks are the azapi blocks whose parent_id is validated, the keys are like resource.azapi_resource
+var parentScopeValidatedBlocks = map[string]bool{
+	"resource.azapi_resource":  true,
+	"data.azapi_resource":      true,
+	"data.azapi_resource_list": true,
+	"data.azapi_resource_id":   true,
+}
+
+// ValidateParentScopes checks the parent_id of the azapi blocks in the file, see ValidateParentScope
+func ValidateParentScopes(file *hcl.File, resolveParentType ParentTypeResolver) hcl.Diagnostics {
+	if file == nil || resolveParentType == nil {
+		return nil
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	diags := make([]*hcl.Diagnostic, 0)
+	for _, block := range body.Blocks {
+		if diag := ValidateParentScope(block, resolveParentType); diag != nil {
+			diags = append(diags, diag)
+		}
+	}
+	return diags
+}
+
+// ValidateParentScope returns a warning if the parent_id of the azapi block refers to a resource which can't be the
+// parent of the resource type. Child resources must be deployed to their parent resources, the other resources must
+// be deployed to one of the scopes defined in the azure schema, the read only scopes are also accepted for data sources.
+func ValidateParentScope(block *hclsyntax.Block, resolveParentType ParentTypeResolver) *hcl.Diagnostic {
+	if block == nil || len(block.Labels) == 0 || !parentScopeValidatedBlocks[block.Type+"."+block.Labels[0]] {
+		return nil
+	}
+	if attr := parser.AttributeWithName(block, "schema_validation_enabled"); attr != nil {
+		if enabled := parser.ToLiteralBoolean(attr.Expr); enabled != nil && !*enabled {
+			return nil
+		}
+	}
+	attribute := parser.AttributeWithName(block, "parent_id")
+	if attribute == nil {
+		return nil
+	}
+	typeValue := parser.ExtractAzureResourceType(block)
+	if typeValue == nil {
+		return nil
+	}
+	parts := strings.Split(*typeValue, "@")
+	if len(parts) != 2 || parts[0] == "" {
+		return nil
+	}
+	resourceType, apiVersion := parts[0], parts[1]
+	parentType := resolveParentType(attribute.Expr)
+	if parentType == "" {
+		return nil
+	}
+
+	if segments := strings.Split(resourceType, "/"); len(segments) > 2 {
+		if strings.EqualFold(parentType, strings.Join(segments[:len(segments)-1], "/")) {
+			return nil
+		}
+	} else {
+		def, err := azure.GetResourceDefinition(resourceType, apiVersion)
+		if err != nil || def == nil {
+			return nil
+		}
+		scopeTypes := def.ScopeTypes
+		if block.Type == "data" {
+			scopeTypes = append(append([]types.ScopeType{}, scopeTypes...), def.ReadOnlyScopeTypes...)
+		}
+		scopeType := parentScopeType(parentType)
+		for _, t := range scopeTypes {
+			// the scopes of some resource types are unknown, they're not validated
+			if t == types.Unknown || t == scopeType {
+				return nil
+			}
+		}
+		if len(scopeTypes) == 0 {
+			return nil
+		}
+	}
+
+	expected := tfschema.ExpectedParentScopes(resourceType, apiVersion)
+	if len(expected) == 0 {
+		return nil
+	}
+	return newDiagnostic(rules.ParentScope, ErrorUnexpectedParentScope(resourceType, tfschema.ScopeLabel(parentType), expected), attribute.Expr.Range())
+}
+
+// parentScopeType returns the scope type which the parent resource type represents,
+// resources other than the tenant, management groups, subscriptions and resource groups are extension scopes
+func parentScopeType(parentType string) types.ScopeType {
+	switch tfschema.ScopeLabel(parentType) {
+	case "tenant":
+		return types.Tenant
+	case "management group":
+		return types.ManagementGroup
+	case "subscription":
+		return types.Subscription
+	case "resource group":
+		return types.ResourceGroup
+	}
+	return types.Extension
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// suppressionPrefix starts the comments which suppress the diagnostics, e.g. `# ms-terraform:ignore AZ001`
+const suppressionPrefix = "ms-terraform:ignore"
+
+// providerDisabled returns true if the validation of the provider which the block belongs to is turned off
+func providerDisabled(opts rules.Options, block *hclsyntax.Block) bool {
+	if len(block.Labels) == 0 {
+		return false
+	}
+	return opts.DisabledProviders[strings.SplitN(block.Labels[0], "_", 2)[0]]
+}
+
+// diagnosticExtra is attached to the Extra field of the diagnostics, it carries the rule and the fix
+type diagnosticExtra struct {
+	rule rules.Rule
+	fix  *Fix
+}
+
+// UnwrapDiagnosticExtra returns the fix, so it can be found by hcl.DiagnosticExtra
+func (e *diagnosticExtra) UnwrapDiagnosticExtra() interface{} {
+	if e.fix == nil {
+		return nil
+	}
+	return e.fix
+}
+
+// DiagnosticCode returns the code of the rule, it's reported as the code of the LSP diagnostic
+func (e *diagnosticExtra) DiagnosticCode() string {
+	return e.rule.Code
+}
+
+// DiagnosticRule returns the rule of the diagnostic, or false if it's not reported by the validation
+func DiagnosticRule(diag *hcl.Diagnostic) (rules.Rule, bool) {
+	extra, ok := hcl.DiagnosticExtra[*diagnosticExtra](diag)
+	if !ok || extra == nil {
+		return rules.Rule{}, false
+	}
+	return extra.rule, true
+}
+
+// hasRule returns true if the diagnostic is reported by the rule
+func hasRule(diag *hcl.Diagnostic, rule rules.Rule) bool {
+	r, ok := DiagnosticRule(diag)
+	return ok && r.Code == rule.Code
+}
+
+// applyOptions removes the suppressed diagnostics and the diagnostics of the rules which are turned off,
+// and applies the severities of the other rules
+func applyOptions(src []byte, filename string, diags hcl.Diagnostics, opts rules.Options) hcl.Diagnostics {
+	suppressions := suppressedRules(src, filename)
+	res := make(hcl.Diagnostics, 0, len(diags))
+	for _, diag := range diags {
+		rule, ok := DiagnosticRule(diag)
+		if !ok {
+			res = append(res, diag)
+			continue
+		}
+		if diag.Subject != nil {
+			line := diag.Subject.Start.Line
+			if suppressions[line][rule.Code] || suppressions[line][rule.Name] {
+				continue
+			}
+		}
+		severity, ok := opts.Severities[rule.Code]
+		if !ok {
+			severity = opts.Severities[rule.Name]
+		}
+		switch strings.ToLower(severity) {
+		case rules.SeverityOff:
+			continue
+		case rules.SeverityError:
+			diag.Severity = hcl.DiagError
+		case rules.SeverityWarning:
+			diag.Severity = hcl.DiagWarning
+		}
+		res = append(res, diag)
+	}
+	return res
+}
+
+// suppressedRules returns the codes and names of the suppressed rules by lines, a suppression comment applies to
+// its own line, and also to the next line if there's nothing else before the comment
+func suppressedRules(src []byte, filename string) map[int]map[string]bool {
+	res := make(map[int]map[string]bool)
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+	for i, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+		text := strings.TrimSpace(string(token.Bytes))
+		text = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(text, "#"), "//"))
+		if !strings.HasPrefix(text, suppressionPrefix) {
+			continue
+		}
+		codes := strings.FieldsFunc(strings.TrimPrefix(text, suppressionPrefix), func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		lines := []int{token.Range.Start.Line}
+		if i == 0 || tokens[i-1].Range.End.Line < token.Range.Start.Line || tokens[i-1].Type == hclsyntax.TokenNewline {
+			lines = append(lines, token.Range.Start.Line+1)
+		}
+		for _, line := range lines {
+			if res[line] == nil {
+				res[line] = make(map[string]bool)
+			}
+			for _, code := range codes {
+				res[line][code] = true
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,245 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// sensitiveBodyBlocks are the azapi resources which support the sensitive_body attribute
+var sensitiveBodyBlocks = map[string]bool{
+	"resource.azapi_resource":        true,
+	"resource.azapi_update_resource": true,
+}
+
+type sensitiveProperty struct {
+	// path is the keys from the body to the property, e.g. [properties, administratorLoginPassword]
+	path   []string
+	parent *parser.HclNode
+	node   *parser.HclNode
+}
+
+// ValidateSensitiveProperties returns a warning for each property in body which is marked sensitive in the schema,
+// the fix moves the property into sensitive_body, so its value doesn't show up in the plan output.
+func ValidateSensitiveProperties(file *parser.File, block *hclsyntax.Block) hcl.Diagnostics {
+	if block == nil || len(block.Labels) == 0 || !sensitiveBodyBlocks[block.Type+"."+block.Labels[0]] {
+		return nil
+	}
+	if attr := parser.AttributeWithName(block, "schema_validation_enabled"); attr != nil {
+		if enabled := parser.ToLiteralBoolean(attr.Expr); enabled != nil && !*enabled {
+			return nil
+		}
+	}
+	bodyAttribute := parser.AttributeWithName(block, "body")
+	if bodyAttribute == nil {
+		return nil
+	}
+	bodyDef := tfschema.BodyDefinitionFromBlock(block)
+	if bodyDef == nil {
+		return nil
+	}
+	// the body is parsed on its own, because the sensitive_body is merged into the node by bodyHclNode
+	hclNode := file.AttributeHclNode(bodyAttribute, true)
+	if hclNode == nil || hclNode.Children["dummy"] == nil {
+		return nil
+	}
+	dummy := hclNode.Children["dummy"]
+
+	diags := make([]*hcl.Diagnostic, 0)
+	for _, property := range sensitiveProperties(dummy, bodyDef.AsTypeBase(), nil) {
+		diag := newDiagnostic(rules.SensitiveProperty, ErrorSensitiveProperty(strings.Join(property.path, ".")), property.node.KeyRange)
+		diags = append(diags, withFix(diag, moveToSensitiveBodyFix(file, block, bodyAttribute, property)))
+	}
+	return diags
+}
+
+// sensitiveProperties returns the properties whose types are marked sensitive, the properties in arrays are skipped,
+// because they can't be moved into sensitive_body without the other items
+func sensitiveProperties(hclNode *parser.HclNode, typeBase *types.TypeBase, path []string) []sensitiveProperty {
+	if hclNode == nil || typeBase == nil || *typeBase == nil {
+		return nil
+	}
+	res := make([]sensitiveProperty, 0)
+	switch t := (*typeBase).(type) {
+	case *types.ResourceType:
+		if t.Body != nil {
+			return sensitiveProperties(hclNode, t.Body.Type, path)
+		}
+	case *types.ObjectType:
+		res = append(res, sensitiveObjectProperties(hclNode, t.Properties, path)...)
+	case *types.DiscriminatedObjectType:
+		res = append(res, sensitiveObjectProperties(hclNode, t.BaseProperties, path)...)
+		if discriminator := hclNode.Children[t.Discriminator]; discriminator != nil {
+			if value, ok := stringLiteralValue(discriminator); ok && t.Elements[value] != nil {
+				res = append(res, sensitiveProperties(hclNode, t.Elements[value].Type, path)...)
+			}
+		}
+	}
+	return res
+}
+
+func sensitiveObjectProperties(hclNode *parser.HclNode, properties map[string]types.ObjectProperty, path []string) []sensitiveProperty {
+	if !hclNode.IsValueMap() {
+		return nil
+	}
+	keys := make([]string, 0, len(hclNode.Children))
+	for key := range hclNode.Children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := make([]sensitiveProperty, 0)
+	for _, key := range keys {
+		def, ok := properties[key]
+		if !ok || def.IsReadOnly() || def.Type == nil {
+			continue
+		}
+		child := hclNode.Children[key]
+		childPath := append(append([]string{}, path...), key)
+		if isSensitiveType(def.Type.Type) {
+			res = append(res, sensitiveProperty{path: childPath, parent: hclNode, node: child})
+			continue
+		}
+		res = append(res, sensitiveProperties(child, def.Type.Type, childPath)...)
+	}
+	return res
+}
+
+func isSensitiveType(typeBase *types.TypeBase) bool {
+	if typeBase == nil || *typeBase == nil {
+		return false
+	}
+	switch t := (*typeBase).(type) {
+	case *types.StringType:
+		return t.Sensitive
+	case *types.ObjectType:
+		return t.Sensitive
+	}
+	return false
+}
+
+// moveToSensitiveBodyFix removes the property from body and inserts it into sensitive_body, the sensitive_body is
+// added after the body if it's not defined. There's no fix if the sensitive_body isn't an object or the property is
+// already defined in it.
+func moveToSensitiveBodyFix(file *parser.File, block *hclsyntax.Block, bodyAttribute *hclsyntax.Attribute, property sensitiveProperty) *Fix {
+	removal := removePropertyFix(property.parent, property.node)
+	r := property.node.ValueRange
+	if removal == nil || r.Start.Byte < 0 || r.End.Byte > len(file.Src) || r.Start.Byte >= r.End.Byte {
+		return nil
+	}
+	value := string(file.Src[r.Start.Byte:r.End.Byte])
+
+	var insertion Edit
+	sensitiveBodyAttribute := parser.AttributeWithName(block, "sensitive_body")
+	if sensitiveBodyAttribute == nil {
+		indent := bodyAttribute.NameRange.Start.Column - 1
+		pos := bodyAttribute.SrcRange.End
+		insertion = Edit{
+			Range:   hcl.Range{Filename: bodyAttribute.SrcRange.Filename, Start: pos, End: pos},
+			NewText: "\n" + strings.Repeat(" ", indent) + "sensitive_body = " + sensitiveObjectText(property.path, value, indent, parser.KeyEqualValue),
+		}
+	} else {
+		hclNode := file.AttributeHclNode(sensitiveBodyAttribute, false)
+		if hclNode == nil {
+			return nil
+		}
+		current := hclNode.Children["dummy"]
+		if current == nil || current.Value != nil || current.ValueRange.Empty() {
+			return nil
+		}
+		current = keyedCopy(current, sensitiveBodyAttribute.NameRange)
+		i := 0
+		for ; i < len(property.path)-1; i++ {
+			child := current.Children[property.path[i]]
+			if child == nil {
+				break
+			}
+			if child.Value != nil || !child.IsValueMap() {
+				return nil
+			}
+			current = child
+		}
+		if i == len(property.path)-1 && current.Children[property.path[i]] != nil {
+			return nil
+		}
+
+		if current.KeyRange.Start.Byte < 0 || current.KeyRange.Start.Byte > len(file.Src) || current.ValueRange.Start.Byte >= len(file.Src) {
+			return nil
+		}
+		indent := lineIndent(file.Src, current.KeyRange.Start.Byte)
+		remaining := property.path[i:]
+		text := sensitivePropertyKey(remaining[0], current.KeyValueFormat) + value
+		if len(remaining) > 1 {
+			text = sensitivePropertyKey(remaining[0], current.KeyValueFormat) + sensitiveObjectText(remaining[1:], value, indent+2, current.KeyValueFormat)
+		}
+		start := current.ValueRange.Start
+		start.Byte++
+		start.Column++
+		end := start
+		newText := "\n" + strings.Repeat(" ", indent+2) + text
+		if len(current.Children) == 0 {
+			newText += "\n" + strings.Repeat(" ", indent)
+		} else {
+			if current.KeyValueFormat == parser.QuotedKeyColonValue {
+				newText += ","
+			}
+			// the existing members may follow the brace on the same line, e.g. { version = "12.0" }, they're moved
+			// to the next line and the spaces before them are replaced
+			spaces := len(file.Src[start.Byte:]) - len(bytes.TrimLeft(file.Src[start.Byte:], " \t"))
+			if rest := file.Src[start.Byte+spaces:]; !bytes.HasPrefix(rest, []byte("\n")) && !bytes.HasPrefix(rest, []byte("\r\n")) {
+				end.Byte += spaces
+				end.Column += spaces
+				newText += "\n" + strings.Repeat(" ", indent+2)
+			}
+		}
+		insertion = Edit{
+			Range:   hcl.Range{Filename: current.ValueRange.Filename, Start: start, End: end},
+			NewText: newText,
+		}
+	}
+
+	return &Fix{
+		Title:           fmt.Sprintf("Move `%s` to `sensitive_body`", strings.Join(property.path, ".")),
+		Range:           removal.Range,
+		AdditionalEdits: []Edit{insertion},
+	}
+}
+
+// lineIndent returns the number of the leading spaces of the line which contains the offset
+func lineIndent(src []byte, offset int) int {
+	start := bytes.LastIndexByte(src[:offset], '\n') + 1
+	n := 0
+	for start+n < len(src) && src[start+n] == ' ' {
+		n++
+	}
+	return n
+}
+
+// sensitiveObjectText returns the object which contains the value at the path, e.g. { properties = { key = value } }
+func sensitiveObjectText(path []string, value string, indent int, format parser.KeyValueFormat) string {
+	inner := value
+	if len(path) > 1 {
+		inner = sensitiveObjectText(path[1:], value, indent+2, format)
+	}
+	return "{\n" + strings.Repeat(" ", indent+2) + sensitivePropertyKey(path[0], format) + inner + "\n" + strings.Repeat(" ", indent) + "}"
+}
+
+// sensitivePropertyKey returns the key and the separator in the format of the object, the keys which aren't valid
+// identifiers are always quoted
+func sensitivePropertyKey(key string, format parser.KeyValueFormat) string {
+	switch {
+	case format == parser.QuotedKeyColonValue:
+		return fmt.Sprintf(`"%s": `, key)
+	case format == parser.QuotedKeyEqualValue || !hclsyntax.ValidIdentifier(key):
+		return fmt.Sprintf(`"%s" = `, key)
+	}
+	return key + " = "
+}
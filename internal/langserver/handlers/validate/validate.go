@@ -2,49 +2,112 @@ package validate
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/ms-terraform-lsp/internal/azure/types"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/diagnostics"
 	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
 	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
 	"github.com/Azure/ms-terraform-lsp/internal/utils"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
-func NewDiagnostics(src []byte, filename string) diagnostics.Diagnostics {
+// DiagnosticSource is the source of the diagnostics reported by the schema validation
+const DiagnosticSource = "schema validate"
+
+// validatedAzAPIBlocks are the azapi resources, data sources and ephemeral resources whose body is validated,
+// the keys are like resource.azapi_resource
+var validatedAzAPIBlocks = map[string]bool{
+	"resource.azapi_resource":            true,
+	"resource.azapi_update_resource":     true,
+	"resource.azapi_resource_action":     true,
+	"data.azapi_resource_action":         true,
+	"ephemeral.azapi_resource_action":    true,
+	"resource.azapi_data_plane_resource": true,
+}
+
+// NewDiagnostics validates the file and returns the diagnostics to publish, the parent_id of the azapi resources is
+// also validated when resolveParentType is not nil, and the declarations are checked against the other files in the
+// module when mod is not nil
+func NewDiagnostics(file *parser.File, mod *module.Module, resolveParentType ParentTypeResolver, opts rules.Options) diagnostics.Diagnostics {
 	diags := diagnostics.NewDiagnostics()
-	_, schemaDiags := ValidateFile(src, filename)
+	hclFile, schemaDiags := ValidateParsedFile(file, opts)
+	if opts.DisabledProviders["azapi"] {
+		resolveParentType = nil
+	}
+	moduleDiags := append(ValidateParentScopes(hclFile, resolveParentType), ValidateDuplicateDeclarations(mod, file.Filename)...)
+	schemaDiags = append(schemaDiags, applyOptions(file.Src, file.Filename, moduleDiags, opts)...)
 	diags.EmptyRootDiagnostic()
 	validateDiags := make(map[string]hcl.Diagnostics)
-	validateDiags[filename] = schemaDiags
-	diags.Append("schema validate", validateDiags)
+	validateDiags[file.Filename] = schemaDiags
+	diags.Append(DiagnosticSource, validateDiags)
 	return diags
 }
 
+// ValidateFile validates the file with the default options
 func ValidateFile(src []byte, filename string) (*hcl.File, hcl.Diagnostics) {
-	file, _ := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
-	if file == nil {
-		return nil, nil
-	}
-	body, isHcl := file.Body.(*hclsyntax.Body)
-	if !isHcl {
+	return ValidateFileWithOptions(src, filename, rules.DefaultOptions())
+}
+
+// ValidateFileWithOptions validates the azapi, msgraph and azurerm blocks in the file, the diagnostics which are
+// suppressed by comments or turned off in the options are removed
+func ValidateFileWithOptions(src []byte, filename string, opts rules.Options) (*hcl.File, hcl.Diagnostics) {
+	return ValidateParsedFile(parser.ParseFile(src, filename), opts)
+}
+
+// ValidateParsedFile validates the parsed file like ValidateFileWithOptions, the hcl nodes built by the file are reused
+func ValidateParsedFile(file *parser.File, opts rules.Options) (*hcl.File, hcl.Diagnostics) {
+	body := file.Body
+	if body == nil {
 		return nil, nil
 	}
+	src := file.Src
 
 	diags := make([]*hcl.Diagnostic, 0)
 	for _, block := range body.Blocks {
-		if block.Type == "resource" && len(block.Labels) > 0 && block.Labels[0] == "azapi_resource" {
-			if diag := ValidateAzAPIBlock(src, block); diag != nil {
+		if providerDisabled(opts, block) {
+			continue
+		}
+		if len(block.Labels) > 0 && validatedAzAPIBlocks[block.Type+"."+block.Labels[0]] {
+			if diag := ValidateAzAPIBlock(file, block); diag != nil {
 				diags = append(diags, diag...)
 			}
 		}
+		if len(block.Labels) > 0 && apiVersionValidatedBlocks[block.Type+"."+block.Labels[0]] {
+			if diag := ValidateApiVersion(file, block, opts.ApiVersionPolicy); diag != nil {
+				diags = append(diags, diag)
+			}
+		}
+		if len(block.Labels) > 0 && sensitiveBodyBlocks[block.Type+"."+block.Labels[0]] {
+			diags = append(diags, ValidateSensitiveProperties(file, block)...)
+		}
+		if len(block.Labels) > 0 && strings.HasPrefix(block.Labels[0], "azapi_") {
+			if diag := ValidateAzAPIType(block); diag != nil {
+				diags = append(diags, diag)
+			}
+		}
+		if len(block.Labels) > 0 && validatedMSGraphBlocks[block.Type+"."+block.Labels[0]] {
+			diags = append(diags, ValidateMSGraphBlock(file, block)...)
+		}
+		if (block.Type == "resource" || block.Type == "data") && len(block.Labels) > 0 && strings.HasPrefix(block.Labels[0], "azurerm_") {
+			diags = append(diags, ValidateAzureRMBlock(src, block)...)
+		}
+	}
+
+	for _, diag := range diags {
+		if fix := DiagnosticFix(diag); fix != nil && fix.NewText == "" {
+			fix.Range = expandDeletionRange(src, fix.Range)
+		}
 	}
-	return file, diags
+	return file.File, applyOptions(src, file.Filename, diags, opts)
 }
 
-func ValidateAzAPIBlock(src []byte, block *hclsyntax.Block) hcl.Diagnostics {
+func ValidateAzAPIBlock(file *parser.File, block *hclsyntax.Block) hcl.Diagnostics {
 	if block == nil {
 		return nil
 	}
@@ -60,38 +123,45 @@ func ValidateAzAPIBlock(src []byte, block *hclsyntax.Block) hcl.Diagnostics {
 		return nil
 	}
 
+	// the body of the action is the input of the resource function, it's not validated if the action isn't specified,
+	// because the request is sent to the resource itself and there's no schema for it
+	if block.Labels[0] == "azapi_resource_action" {
+		if action := parser.ExtractAction(block); action == nil || *action == "" {
+			return nil
+		}
+	}
+
 	bodyDef := tfschema.BodyDefinitionFromBlock(block)
 	if bodyDef == nil {
 		return nil
 	}
+	return validateAzAPIBody(file, block, bodyDef)
+}
 
-	var attribute *hclsyntax.Attribute
-	var hclNode *parser.HclNode
-	if bodyAttribute := parser.AttributeWithName(block, "body"); bodyAttribute != nil {
-		attribute = bodyAttribute
-		hclNode = parser.JsonEncodeExpressionToHclNode(src, attribute.Expr)
-		if hclNode == nil {
-			tokens, _ := hclsyntax.LexExpression(src[attribute.Expr.Range().Start.Byte:attribute.Expr.Range().End.Byte], "", attribute.Expr.Range().Start)
-			hclNode = parser.BuildHclNode(tokens)
-		}
-	}
-	if sensitiveBodyAttribute := parser.AttributeWithName(block, "sensitive_body"); sensitiveBodyAttribute != nil {
-		tokens, _ := hclsyntax.LexExpression(src[sensitiveBodyAttribute.Expr.Range().Start.Byte:sensitiveBodyAttribute.Expr.Range().End.Byte], "", sensitiveBodyAttribute.Expr.Range().Start)
-		sensitiveHclNode := parser.BuildHclNode(tokens)
-		hclNode = parser.CombineHclNodes(hclNode, sensitiveHclNode)
-	}
+// validateAzAPIBody validates the body and sensitive_body of the azapi block against the body definition
+func validateAzAPIBody(file *parser.File, block *hclsyntax.Block, bodyDef types.TypeBase) hcl.Diagnostics {
+	attribute, hclNode := bodyHclNode(file, block)
 	if attribute == nil || hclNode == nil {
 		return nil
 	}
 
 	if dummy, ok := hclNode.Children["dummy"]; ok {
-		dummy.KeyRange = attribute.NameRange
+		dummy = keyedCopy(dummy, attribute.NameRange)
 		if nameAttribute := parser.AttributeWithName(block, "name"); nameAttribute != nil {
-			if dummy.Children == nil {
-				dummy.Children = make(map[string]*parser.HclNode)
+			children := make(map[string]*parser.HclNode, len(dummy.Children)+1)
+			for key, child := range dummy.Children {
+				children[key] = child
+			}
+			dummy.Children = children
+			// the value of the node is the source text, so it can be validated like the properties in body
+			var nameValue *string
+			if parser.ToLiteral(nameAttribute.Expr) != nil {
+				r := nameAttribute.Expr.Range()
+				v := string(file.Src[r.Start.Byte:r.End.Byte])
+				nameValue = &v
 			}
 			dummy.Children["name"] = &parser.HclNode{
-				Value:      parser.ToLiteral(nameAttribute.Expr),
+				Value:      nameValue,
 				Key:        "name",
 				KeyRange:   nameAttribute.NameRange,
 				ValueRange: nameAttribute.Expr.Range(),
@@ -102,8 +172,7 @@ func ValidateAzAPIBlock(src []byte, block *hclsyntax.Block) hcl.Diagnostics {
 		if block.Labels[0] == "azapi_update_resource" {
 			res := hcl.Diagnostics{}
 			for _, diag := range diags {
-				// TODO: don't hardcode here
-				if !strings.HasSuffix(diag.Summary, " is required, but no definition was found") {
+				if !hasRule(diag, rules.MissingRequiredProperty) {
 					res = append(res, diag)
 				}
 			}
@@ -115,6 +184,26 @@ func ValidateAzAPIBlock(src []byte, block *hclsyntax.Block) hcl.Diagnostics {
 	return nil
 }
 
+// bodyHclNode returns the body attribute and the hcl node built from the body and sensitive_body attributes
+func bodyHclNode(file *parser.File, block *hclsyntax.Block) (*hclsyntax.Attribute, *parser.HclNode) {
+	var hclNode *parser.HclNode
+	attribute := parser.AttributeWithName(block, "body")
+	if attribute != nil {
+		hclNode = file.AttributeHclNode(attribute, true)
+	}
+	if sensitiveBodyAttribute := parser.AttributeWithName(block, "sensitive_body"); sensitiveBodyAttribute != nil {
+		hclNode = parser.CombineHclNodes(hclNode, file.AttributeHclNode(sensitiveBodyAttribute, false))
+	}
+	return attribute, hclNode
+}
+
+// keyedCopy returns a copy of the node with the key range, the nodes built by the file are shared and aren't modified
+func keyedCopy(hclNode *parser.HclNode, keyRange hcl.Range) *parser.HclNode {
+	res := *hclNode
+	res.KeyRange = keyRange
+	return &res
+}
+
 func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics {
 	if typeBase == nil || hclNode == nil {
 		return nil
@@ -138,7 +227,7 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 		for key, value := range hclNode.Children {
 			if def, ok := t.BaseProperties[key]; ok {
 				if def.IsReadOnly() {
-					diags = append(diags, newDiagnostic(ErrorShouldNotDefineReadOnly(key), value.KeyRange))
+					diags = append(diags, withFix(newDiagnostic(rules.ReadOnlyProperty, ErrorShouldNotDefineReadOnly(key), value.KeyRange), removePropertyFix(hclNode, value)))
 					continue
 				}
 				if def.Type != nil {
@@ -152,13 +241,13 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 		// check required base properties
 		for key, value := range t.BaseProperties {
 			if value.IsRequired() && hclNode.Children[key] == nil {
-				diags = append(diags, newDiagnostic(ErrorShouldDefine(key), hclNode.KeyRange))
+				diags = append(diags, withFix(newDiagnostic(rules.MissingRequiredProperty, ErrorShouldDefine(key), hclNode.KeyRange), insertPropertyFix(hclNode, key, placeholderValue(propertyType(value)))))
 			}
 		}
 
 		// check other properties which should be defined in discriminated objects
 		if _, ok := otherProperties[t.Discriminator]; !ok {
-			diags = append(diags, newDiagnostic(ErrorShouldDefine(t.Discriminator), hclNode.KeyRange))
+			diags = append(diags, withFix(newDiagnostic(rules.MissingRequiredProperty, ErrorShouldDefine(t.Discriminator), hclNode.KeyRange), insertPropertyFix(hclNode, t.Discriminator, placeholderValue(nil))))
 			break
 		}
 
@@ -180,7 +269,8 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 				for key := range t.Elements {
 					options = append(options, key)
 				}
-				diags = append(diags, newDiagnostic(ErrorNotMatchAnyValues(t.Discriminator, discriminator, options), discriminatorProp.ValueRange))
+				sort.Strings(options)
+				diags = append(diags, withFix(newDiagnostic(rules.InvalidValue, ErrorNotMatchAnyValues(t.Discriminator, discriminator, options), discriminatorProp.ValueRange), replaceValueFix(discriminatorProp, options)))
 			case t.Elements[discriminator].Type != nil:
 				other := &parser.HclNode{
 					Key:        hclNode.Key,
@@ -192,7 +282,7 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 				diags = append(diags, Validate(other, t.Elements[discriminator].Type)...)
 			}
 		} else {
-			diags = append(diags, newDiagnostic(ErrorMismatch(t.Discriminator, "string", fmt.Sprintf("%T", otherProperties[t.Discriminator])), discriminatorRange))
+			diags = append(diags, newDiagnostic(rules.InvalidValue, ErrorMismatch(t.Discriminator, "string", fmt.Sprintf("%T", otherProperties[t.Discriminator])), discriminatorRange))
 		}
 	case *types.ObjectType:
 		if !hclNode.IsValueMap() {
@@ -202,7 +292,7 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 		for key, value := range hclNode.Children {
 			if def, ok := t.Properties[key]; ok {
 				if def.IsReadOnly() {
-					diags = append(diags, newDiagnostic(ErrorShouldNotDefineReadOnly(key), value.KeyRange))
+					diags = append(diags, withFix(newDiagnostic(rules.ReadOnlyProperty, ErrorShouldNotDefineReadOnly(key), value.KeyRange), removePropertyFix(hclNode, value)))
 					continue
 				}
 				if def.Type != nil {
@@ -217,7 +307,8 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 				for key := range t.Properties {
 					options = append(options, key)
 				}
-				diags = append(diags, newDiagnostic(ErrorShouldNotDefine(key, options), value.KeyRange))
+				sort.Strings(options)
+				diags = append(diags, withFix(newDiagnostic(rules.UnknownProperty, ErrorShouldNotDefine(key, options), value.KeyRange), renamePropertyFix(value, getSuggestion(key, options))))
 			}
 		}
 
@@ -228,7 +319,7 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 				if hclNode.Key == "dummy" && (key == "name" || key == "location") {
 					continue
 				}
-				diags = append(diags, newDiagnostic(ErrorShouldDefine(key), hclNode.KeyRange))
+				diags = append(diags, withFix(newDiagnostic(rules.MissingRequiredProperty, ErrorShouldDefine(key), hclNode.KeyRange), insertPropertyFix(hclNode, key, placeholderValue(propertyType(value)))))
 			}
 		}
 	case *types.ResourceType:
@@ -242,16 +333,28 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 	case *types.AnyType:
 	case *types.BooleanType:
 	case *types.IntegerType:
-		// TODO: validate
+		if hclNode.Value == nil {
+			break
+		}
+		// only integer literals are validated, the expressions are evaluated by terraform
+		if value, err := strconv.Atoi(strings.TrimSpace(*hclNode.Value)); err == nil {
+			for _, err := range t.Validate(value, hclNode.Key) {
+				diags = append(diags, newDiagnostic(rules.ValueConstraint, err.Error(), hclNode.ValueRange))
+			}
+		}
 	case *types.StringType:
-		// TODO: validate
+		if value, ok := stringLiteralValue(hclNode); ok {
+			for _, err := range t.Validate(value, hclNode.Key) {
+				diags = append(diags, newDiagnostic(rules.ValueConstraint, err.Error(), hclNode.ValueRange))
+			}
+		}
 	case *types.StringLiteralType:
 		if hclNode.Value != nil {
 			value := strings.TrimSpace(*hclNode.Value)
 			if strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
 				value = strings.TrimPrefix(strings.TrimSuffix(value, `"`), `"`)
 				if value != t.Value {
-					diags = append(diags, newDiagnostic(ErrorMismatch(hclNode.Key, t.Value, value), hclNode.ValueRange))
+					diags = append(diags, withFix(newDiagnostic(rules.InvalidValue, ErrorMismatch(hclNode.Key, t.Value, value), hclNode.ValueRange), literalValueFix(hclNode, t.Value)))
 				}
 			}
 		}
@@ -277,24 +380,49 @@ func Validate(hclNode *parser.HclNode, typeBase *types.TypeBase) hcl.Diagnostics
 				}
 			}
 			if len(options) == 0 {
-				diags = append(diags, newDiagnostic(ErrorNotMatchAny(hclNode.Key), hclNode.GetRange()))
+				diags = append(diags, newDiagnostic(rules.InvalidValue, ErrorNotMatchAny(hclNode.Key), hclNode.GetRange()))
 			} else {
 				value := ""
 				if hclNode.Value != nil {
 					value = *hclNode.Value
 				}
-				diags = append(diags, newDiagnostic(ErrorNotMatchAnyValues(hclNode.Key, value, options), hclNode.ValueRange))
+				diags = append(diags, withFix(newDiagnostic(rules.InvalidValue, ErrorNotMatchAnyValues(hclNode.Key, value, options), hclNode.ValueRange), replaceValueFix(hclNode, options)))
 			}
 		}
 	}
 	return diags
 }
 
-func newDiagnostic(summary string, r hcl.Range) *hcl.Diagnostic {
+// stringLiteralValue returns the value of the quoted string literal, the templates with interpolations are skipped
+func stringLiteralValue(hclNode *parser.HclNode) (string, bool) {
+	if hclNode.Value == nil {
+		return "", false
+	}
+	value := strings.TrimSpace(*hclNode.Value)
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) || strings.Contains(value, "${") || strings.Contains(value, "%{") {
+		return "", false
+	}
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return "", false
+	}
+	return unquoted, true
+}
+
+func propertyType(property types.ObjectProperty) *types.TypeBase {
+	if property.Type == nil {
+		return nil
+	}
+	return property.Type.Type
+}
+
+// newDiagnostic returns the diagnostic of the rule with its default severity
+func newDiagnostic(rule rules.Rule, summary string, r hcl.Range) *hcl.Diagnostic {
 	return &hcl.Diagnostic{
 		Summary:  summary,
 		Subject:  utils.Range(r),
-		Severity: hcl.DiagError,
+		Severity: rule.Severity,
+		Extra:    &diagnosticExtra{rule: rule},
 	}
 }
 
@@ -328,6 +456,62 @@ func ErrorShouldDefine(key string) string {
 	return fmt.Sprintf("`%s` is required, but no definition was found", strings.TrimPrefix(key, "."))
 }
 
+func ErrorUnknownResourceType(resourceType string, suggestion string) string {
+	return fmt.Sprintf("resource type `%s` is not found. Do you mean `%s`? ", resourceType, suggestion)
+}
+
+func ErrorUnknownApiVersion(resourceType string, apiVersion string, apiVersions []string) string {
+	return fmt.Sprintf("api-version `%s` is not found for `%s`. The available api-versions are [%s]", apiVersion, resourceType, strings.Join(apiVersions, ", "))
+}
+
+func ErrorConflictsWith(key string, conflict string) string {
+	return fmt.Sprintf("`%s` conflicts with `%s`, only one of them can be specified", key, conflict)
+}
+
+func ErrorExactlyOneOf(keys []string) string {
+	return fmt.Sprintf("only one of %s can be specified", joinKeys(keys))
+}
+
+func ErrorExactlyOneOfMissing(keys []string) string {
+	return fmt.Sprintf("exactly one of %s must be specified", joinKeys(keys))
+}
+
+func ErrorAtLeastOneOf(keys []string) string {
+	return fmt.Sprintf("at least one of %s must be specified", joinKeys(keys))
+}
+
+func ErrorRequiredWith(key string, missing []string) string {
+	return fmt.Sprintf("`%s` requires %s to be specified", key, joinKeys(missing))
+}
+
+func ErrorUnexpectedParentScope(resourceType string, parent string, expected []string) string {
+	quoted := make([]string, 0, len(expected))
+	for _, scope := range expected {
+		quoted = append(quoted, fmt.Sprintf("`%s`", scope))
+	}
+	return fmt.Sprintf("`parent_id` refers to `%s`, but `%s` is expected to be deployed to %s", parent, resourceType, strings.Join(quoted, " or "))
+}
+
+func ErrorPreviewApiVersion(apiVersion string, stable string) string {
+	return fmt.Sprintf("api-version `%s` is a preview version, the stable api-version `%s` is available", apiVersion, stable)
+}
+
+func ErrorOutdatedApiVersion(apiVersion string, newer int, latest string) string {
+	return fmt.Sprintf("api-version `%s` is outdated, there are %d newer api-versions and the latest is `%s`", apiVersion, newer, latest)
+}
+
+func ErrorSensitiveProperty(key string) string {
+	return fmt.Sprintf("`%s` is sensitive, it should be defined in `sensitive_body` to keep it out of the plan output", key)
+}
+
+func joinKeys(keys []string) string {
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		quoted = append(quoted, fmt.Sprintf("`%s`", key))
+	}
+	return strings.Join(quoted, ", ")
+}
+
 func getSuggestion(value string, options []string) string {
 	suggestion := ""
 	distance := 1 << 16
@@ -344,7 +528,7 @@ func editDistance(a, b string) int {
 	n, m := len(a), len(b)
 	f := make([][]int, n+1)
 	for i := range f {
-		f[i] = make([]int, m+1, 1<<16)
+		f[i] = make([]int, m+1)
 	}
 	for i := 1; i <= n; i++ {
 		for j := 1; j <= m; j++ {
@@ -369,3 +553,7 @@ func editDistance(a, b string) int {
 	}
 	return f[n][m]
 }
+
+func ErrorDuplicateDeclaration(address string, r hcl.Range) string {
+	return fmt.Sprintf("`%s` is already declared at %s:%d", address, r.Filename, r.Start.Line)
+}
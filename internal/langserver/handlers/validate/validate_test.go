@@ -3,7 +3,15 @@ package validate
 import (
 	"fmt"
 	"os"
+	"sort"
 	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/tfschema"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 func TestValidation_disabled(t *testing.T) {
@@ -103,15 +111,10 @@ func TestValidation_update(t *testing.T) {
 }
 
 func TestValidation_openBracketInValue(t *testing.T) {
-	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	_, diag := ValidateFile(config, "main.tf")
-	if len(diag) != 0 {
-		t.Errorf("expect no diagnostics, but got %v", diag)
-	}
+	// the accountKey is sensitive, it's the only diagnostic if the brackets in its value are parsed correctly
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`properties.azureFile.accountKey` is sensitive, it should be defined in `sensitive_body` to keep it out of the plan output", line: 9},
+	})
 }
 
 func TestValidation_missingRequiredPropertyInArrayItem(t *testing.T) {
@@ -120,7 +123,9 @@ func TestValidation_missingRequiredPropertyInArrayItem(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// the api-version is a preview version, the warning about it is irrelevant to the body
 	_, diag := ValidateFile(config, "main.tf")
+	diag = errorDiagnostics(diag)
 	if len(diag) != 2 {
 		t.Errorf("expect 2 diagnostics, but got %v", diag)
 	}
@@ -140,7 +145,9 @@ func TestValidation_payload_missingRequiredPropertyInArrayItem(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// the api-version is a preview version, the warning about it is irrelevant to the body
 	_, diag := ValidateFile(config, "main.tf")
+	diag = errorDiagnostics(diag)
 	if len(diag) != 2 {
 		t.Errorf("expect 2 diagnostics, but got %v", diag)
 	}
@@ -165,3 +172,365 @@ func TestValidation_sensitiveBody(t *testing.T) {
 		t.Errorf("expect no diagnostics, but got %v", diag)
 	}
 }
+
+func TestValidation_fixNotExpectedProperty(t *testing.T) {
+	testValidationFix(t, "Replace `identity1` with `identity`", false)
+}
+
+func TestValidation_fixReadOnlyProperty(t *testing.T) {
+	testValidationFix(t, "Remove read only property `createTime`", true)
+}
+
+func TestValidation_fixInvalidValue(t *testing.T) {
+	testValidationFix(t, "Replace `AzureCL` with `AzureCLI`", false)
+}
+
+func TestValidation_fixInvalidValueCase(t *testing.T) {
+	testValidationFix(t, "Replace `azurecli` with `AzureCLI`", true)
+}
+
+func TestValidation_fixMissingRequiredProperty(t *testing.T) {
+	testValidationFix(t, "Add required property `vaultBaseUrl`", false)
+}
+
+func TestValidation_fixMissingRequiredPropertyInEmptyObject(t *testing.T) {
+	testValidationFix(t, "Add required property `type`", false)
+}
+
+func TestValidation_resourceAction(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`keyNam` is not expected here. Do you mean `keyName`? ", line: 6},
+		{summary: "`keyName` is required, but no definition was found", line: 5},
+		{summary: "`keyName` is required, but no definition was found", line: 14},
+	})
+}
+
+func TestValidation_updateNotExpectedProperty(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`publicNetworkAcces` is not expected here. Do you mean `publicNetworkAccess`? ", line: 6},
+	})
+}
+
+func TestValidation_dataResourceAction(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`keyType` is not expected here. Do you mean `keyName`? ", line: 16},
+	})
+}
+
+func TestValidation_dataPlaneResource(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`valu` is not expected here. Do you mean `value`? ", line: 19},
+	})
+}
+
+func TestValidation_msgraph(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`signInAudiance` is not expected here. Do you mean `signInAudience`? ", line: 5},
+		{summary: "`redirectUri` is not expected here. Do you mean `redirectUris`? ", line: 9},
+		{summary: "`displayNam` is not expected here. Do you mean `displayName`? ", line: 17},
+	})
+}
+
+func TestValidation_msgraphFixInvalidValue(t *testing.T) {
+	testValidationFix(t, "Replace `Enabled` with `enabled`", true)
+}
+
+func TestValidation_fixUnknownResourceType(t *testing.T) {
+	testValidationFix(t, "Replace `Microsoft.Storage/storageAcounts@2023-01-01` with `Microsoft.Storage/storageAccounts@2023-01-01`", false)
+}
+
+func TestValidation_fixUnknownApiVersion(t *testing.T) {
+	testValidationFix(t, "Replace `Microsoft.Storage/storageAccounts@2023-01-02` with `Microsoft.Storage/storageAccounts@2023-01-01`", false)
+}
+
+func TestValidation_stringPattern(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`name` is invalid, string does not match pattern ^[a-z0-9]+$", line: 4},
+	})
+}
+
+func TestValidation_integerRange(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`days` is invalid, value is greater than 365", line: 9},
+	})
+}
+
+func TestValidation_azurermNotExpectedAttribute(t *testing.T) {
+	testValidationFix(t, "Replace `tag` with `tags`", false)
+}
+
+func TestValidation_azurermMissingRequiredAttribute(t *testing.T) {
+	testValidationFix(t, "Add required property `location`", false)
+}
+
+func TestValidation_azurermInvalidPossibleValue(t *testing.T) {
+	testValidationFix(t, "Replace `standard` with `Standard`", true)
+}
+
+func TestValidation_azurermNestedBlock(t *testing.T) {
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, diags := ValidateFile(config, "main.tf")
+	expected := []string{
+		"`ip_rule` is not expected here. Do you mean `ip_rules`? ",
+		"`default_action` is required, but no definition was found",
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expect %d diagnostics, but got %v", len(expected), diags)
+	}
+	for i, diag := range diags {
+		if diag.Summary != expected[i] {
+			t.Errorf("expect diagnostic %q, but got %q", expected[i], diag.Summary)
+		}
+	}
+}
+
+func TestValidation_azurermConflictsWith(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`source_port_range` conflicts with `source_port_ranges`, only one of them can be specified", line: 7},
+		{summary: "`source_port_ranges` conflicts with `source_port_range`, only one of them can be specified", line: 8},
+	})
+}
+
+func TestValidation_azurermExactlyOneOf(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "exactly one of `address_space`, `ip_address_pool` must be specified", line: 1},
+		{summary: "only one of `address_space`, `ip_address_pool` can be specified", line: 11},
+		{summary: "only one of `address_space`, `ip_address_pool` can be specified", line: 13},
+	})
+}
+
+func TestValidation_azurermRequiredWith(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`restore_policy` requires `delete_retention_policy` to be specified", line: 9},
+	})
+}
+
+type expectedDiagnostic struct {
+	summary string
+	line    int
+}
+
+func TestValidation_parentScope(t *testing.T) {
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod := module.NewModule(".")
+	mod.SetFile("main.tf", config)
+	file, _ := hclsyntax.ParseConfig(config, "main.tf", hcl.InitialPos)
+	diags := ValidateParentScopes(file, func(expr hclsyntax.Expression) string {
+		return tfschema.ParentResourceType(expr, mod, nil)
+	})
+
+	expected := []expectedDiagnostic{
+		{summary: "`parent_id` refers to `resource group`, but `Microsoft.Network/virtualNetworks/subnets` is expected to be deployed to `Microsoft.Network/virtualNetworks`", line: 17},
+		{summary: "`parent_id` refers to `resource group`, but `Microsoft.Management/managementGroups` is expected to be deployed to `tenant`", line: 23},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expect %d diagnostics, but got %v", len(expected), diags)
+	}
+	for i, diag := range diags {
+		if diag.Severity != hcl.DiagWarning {
+			t.Errorf("expect diagnostic %q to be a warning", diag.Summary)
+		}
+		if diag.Summary != expected[i].summary || diag.Subject.Start.Line != expected[i].line {
+			t.Errorf("expect diagnostic %q at line %d, but got %q at line %d", expected[i].summary, expected[i].line, diag.Summary, diag.Subject.Start.Line)
+		}
+	}
+}
+
+func TestValidation_fixSensitiveProperty(t *testing.T) {
+	testValidationFix(t, "Move `properties.administratorLoginPassword` to `sensitive_body`", false)
+}
+
+func TestValidation_fixSensitivePropertyExistingSensitiveBody(t *testing.T) {
+	testValidationFix(t, "Move `properties.administratorLoginPassword` to `sensitive_body`", false)
+}
+
+func TestValidation_fixSensitivePropertySingleLineSensitiveBody(t *testing.T) {
+	testValidationFix(t, "Move `properties.administratorLoginPassword` to `sensitive_body`", false)
+}
+
+func TestValidation_previewApiVersion(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "api-version `2015-05-01-preview` is a preview version, the stable api-version `2024-01-01` is available", line: 2},
+	})
+
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, diags := ValidateFile(config, "main.tf")
+	fix := DiagnosticFix(diags[0])
+	if fix == nil {
+		t.Fatalf("expect a fix of the diagnostic %v", diags[0])
+	}
+	if expected := "Upgrade api-version to `2024-01-01`, `accountType` not defined in the new api-version"; fix.Title != expected {
+		t.Errorf("expect fix %q, but got %q", expected, fix.Title)
+	}
+	if expected := `"Microsoft.Storage/storageAccounts@2024-01-01"`; fix.NewText != expected {
+		t.Errorf("expect the type is replaced with %s, but got %s", expected, fix.NewText)
+	}
+}
+
+func TestValidation_outdatedApiVersion(t *testing.T) {
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := parser.ParseFile(config, "main.tf")
+	block := file.Body.Blocks[0]
+
+	if diag := ValidateApiVersion(file, block, rules.DefaultApiVersionPolicy); diag != nil {
+		t.Errorf("expect no diagnostic with the default policy, but got %v", diag)
+	}
+
+	diag := ValidateApiVersion(file, block, rules.ApiVersionPolicy{MaxNewerVersions: 2})
+	if diag == nil {
+		t.Fatal("expect a diagnostic when the api-version has 2 newer api-versions")
+	}
+	if expected := "api-version `2023-01-01` is outdated, there are 3 newer api-versions and the latest is `2024-01-01`"; diag.Summary != expected {
+		t.Errorf("expect diagnostic %q, but got %q", expected, diag.Summary)
+	}
+	if fix := DiagnosticFix(diag); fix == nil || fix.Title != "Upgrade api-version to `2024-01-01`" {
+		t.Errorf("expect the fix upgrades the api-version, but got %v", fix)
+	}
+}
+
+func TestValidation_suppression(t *testing.T) {
+	testValidationDiagnostics(t, []expectedDiagnostic{
+		{summary: "`tags1` is not expected here. Do you mean `tags`? ", line: 11},
+	})
+}
+
+func TestValidation_severity(t *testing.T) {
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, diags := ValidateFile(config, "main.tf")
+	if len(diags) != 1 || diags[0].Severity != hcl.DiagError {
+		t.Fatalf("expect 1 error, but got %v", diags)
+	}
+	if rule, ok := DiagnosticRule(diags[0]); !ok || rule.Code != "AZ001" {
+		t.Errorf("expect the diagnostic is reported by rule AZ001, but got %v", rule)
+	}
+
+	_, diags = ValidateFileWithOptions(config, "main.tf", rules.Options{Severities: map[string]string{"AZ001": rules.SeverityWarning}})
+	if len(diags) != 1 || diags[0].Severity != hcl.DiagWarning {
+		t.Errorf("expect 1 warning, but got %v", diags)
+	}
+
+	_, diags = ValidateFileWithOptions(config, "main.tf", rules.Options{Severities: map[string]string{"unknown-property": rules.SeverityOff}})
+	if len(diags) != 0 {
+		t.Errorf("expect no diagnostics, but got %v", diags)
+	}
+}
+
+func TestValidation_duplicateDeclaration(t *testing.T) {
+	mod := module.NewModule(".")
+	for _, filename := range []string{"main.tf", "variables.tf"} {
+		src, err := os.ReadFile(fmt.Sprintf("../testdata/%s/%s", t.Name(), filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mod.SetFile(filename, src)
+	}
+
+	if diags := ValidateDuplicateDeclarations(mod, "main.tf"); len(diags) != 0 {
+		t.Errorf("expect no diagnostics in main.tf, but got %v", diags)
+	}
+
+	diags := ValidateDuplicateDeclarations(mod, "variables.tf")
+	expected := []expectedDiagnostic{
+		{summary: "`var.location` is already declared at main.tf:1", line: 1},
+		{summary: "`azapi_resource.test` is already declared at main.tf:5", line: 9},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expect %d diagnostics, but got %v", len(expected), diags)
+	}
+	for i, diag := range diags {
+		if diag.Summary != expected[i].summary || diag.Subject.Start.Line != expected[i].line {
+			t.Errorf("expect diagnostic %q at line %d, but got %q at line %d", expected[i].summary, expected[i].line, diag.Summary, diag.Subject.Start.Line)
+		}
+	}
+}
+
+func errorDiagnostics(diags hcl.Diagnostics) hcl.Diagnostics {
+	res := make(hcl.Diagnostics, 0)
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError {
+			res = append(res, diag)
+		}
+	}
+	return res
+}
+
+// testValidationDiagnostics compares the diagnostics of the config with the expected summaries and start lines
+func testValidationDiagnostics(t *testing.T, expected []expectedDiagnostic) {
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, diags := ValidateFile(config, "main.tf")
+	if len(diags) != len(expected) {
+		t.Fatalf("expect %d diagnostics, but got %v", len(expected), diags)
+	}
+	for i, diag := range diags {
+		if diag.Summary != expected[i].summary || diag.Subject.Start.Line != expected[i].line {
+			t.Errorf("expect diagnostic %q at line %d, but got %q at line %d", expected[i].summary, expected[i].line, diag.Summary, diag.Subject.Start.Line)
+		}
+	}
+}
+
+// testValidationFix applies the fix of the only diagnostic to the config and compares the result with expect.tf
+func testValidationFix(t *testing.T, title string, unambiguous bool) {
+	config, err := os.ReadFile(fmt.Sprintf("../testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile(fmt.Sprintf("../testdata/%s/expect.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, diags := ValidateFile(config, "main.tf")
+	if len(diags) != 1 {
+		t.Fatalf("expect 1 diagnostics, but got %v", diags)
+	}
+	fix := DiagnosticFix(diags[0])
+	if fix == nil {
+		t.Fatalf("expect a fix of the diagnostic %v", diags[0])
+	}
+	if fix.Title != title {
+		t.Errorf("expect fix %q, but got %q", title, fix.Title)
+	}
+	if fix.Unambiguous != unambiguous {
+		t.Errorf("expect the fix is unambiguous: %v, but got %v", unambiguous, fix.Unambiguous)
+	}
+
+	// the edits are applied from the end of the config, so the ranges of the other edits are still valid
+	edits := append([]Edit{{Range: fix.Range, NewText: fix.NewText}}, fix.AdditionalEdits...)
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Range.Start.Byte > edits[j].Range.Start.Byte
+	})
+	actual := string(config)
+	for _, edit := range edits {
+		actual = actual[:edit.Range.Start.Byte] + edit.NewText + actual[edit.Range.End.Byte:]
+	}
+	if actual != string(expected) {
+		t.Errorf("expect:\n%s\nbut got:\n%s", expected, actual)
+	}
+
+	// the fixed config should be valid
+	if _, diags := ValidateFile([]byte(actual), "main.tf"); len(diags) != 0 {
+		t.Errorf("expect no diagnostics after the fix, but got %v", diags)
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver/handlers/command"
+	ilsp "github.com/Azure/ms-terraform-lsp/internal/lsp"
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+)
+
+func (svc *service) WorkspaceSymbol(ctx context.Context, params lsp.WorkspaceSymbolParams) ([]lsp.SymbolInformation, error) {
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(params.Query)
+	symbols := make([]module.Symbol, 0)
+	for _, folder := range svc.folders.List() {
+		// the azurerm mapping is loaded in the background, the ARM resource types of the azurerm resources are only
+		// matched when it's ready
+		settings := svc.folders.Settings(svc.currentSettings(), folder.Path)
+		azurermResourceTypes := command.AzurermResourceTypes(svc.folders.WorkingDirectory(folder.Path), settings)
+
+		// the folder is indexed at initialized and kept current with the watched file changes
+		for _, mod := range folder.Index.Modules(folder.Path) {
+			// the modules in a nested workspace folder are reported by the nested folder
+			if svc.folders.FolderOf(mod.Path) != folder {
+				continue
+			}
+			for _, filename := range mod.Filenames() {
+				for _, symbol := range mod.FileSymbols(filename) {
+					if matchWorkspaceSymbol(symbol, query, azurermResourceTypes) {
+						symbols = append(symbols, symbol)
+					}
+				}
+			}
+		}
+	}
+
+	caps := cc.Workspace.Symbol
+	if caps == nil {
+		caps = &lsp.WorkspaceSymbolClientCapabilities{}
+	}
+	return ilsp.WorkspaceSymbols(symbols, caps), nil
+}
+
+// matchWorkspaceSymbol returns true if the query matches the address, the azapi type, the msgraph url
+// or the ARM resource type of the azurerm resource
+func matchWorkspaceSymbol(symbol module.Symbol, query string, azurermResourceTypes map[string]string) bool {
+	bs, ok := symbol.(*module.BlockSymbol)
+	if !ok || len(bs.Labels) != 2 {
+		return false
+	}
+	switch bs.Type {
+	case "resource", "data", "ephemeral":
+	default:
+		return false
+	}
+
+	candidates := []string{bs.Name(), bs.Detail}
+	if strings.HasPrefix(bs.Labels[0], "azurerm_") {
+		if resourceType, ok := azurermResourceTypes[fmt.Sprintf("%s.%s", bs.Type, bs.Labels[0])]; ok {
+			candidates = append(candidates, resourceType)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if strings.Contains(strings.ToLower(candidate), query) {
+			return true
+		}
+	}
+	return false
+}
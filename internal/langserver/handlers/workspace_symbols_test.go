@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/ms-terraform-lsp/internal/langserver"
+)
+
+func TestWorkspaceSymbol_resourceType(t *testing.T) {
+	tmpDir := TempDir(t, "modules/storage")
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := os.WriteFile(filepath.Join(tmpDir.Dir(), "modules", "storage", "main.tf"), []byte(`resource "azapi_resource" "account" {
+  type = "Microsoft.Storage/storageAccounts@2023-05-01"
+}
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	config, err := os.ReadFile(fmt.Sprintf("./testdata/%s/main.tf", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI()),
+	})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method:    "textDocument/didOpen",
+		ReqParams: buildReqParamsTextDocument(string(config), tmpDir.URI()),
+	})
+
+	ls.CallAndExpectResultEventually(t, &langserver.CallRequest{
+		Method:    "workspace/symbol",
+		ReqParams: `{"query": "storageAccounts"}`,
+	}, fmt.Sprintf(`[
+		{
+			"name": "azurerm_storage_account.sa",
+			"kind": 5,
+			"location": {
+				"uri": "%[1]s/main.tf",
+				"range": {
+					"start": {"line": 5, "character": 0},
+					"end": {"line": 8, "character": 1}
+				}
+			}
+		},
+		{
+			"name": "Microsoft.Storage/storageAccounts@2023-01-01",
+			"kind": 5,
+			"location": {
+				"uri": "%[1]s/main.tf",
+				"range": {
+					"start": {"line": 10, "character": 0},
+					"end": {"line": 14, "character": 1}
+				}
+			},
+			"containerName": "azapi_resource.sa"
+		},
+		{
+			"name": "Microsoft.Storage/storageAccounts@2023-05-01",
+			"kind": 5,
+			"location": {
+				"uri": "%[1]s/modules/storage/main.tf",
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 2, "character": 1}
+				}
+			},
+			"containerName": "azapi_resource.account"
+		}
+	]`, tmpDir.URI()), 5*time.Second)
+}
@@ -155,6 +155,31 @@ func (lsm *langServerMock) CallAndExpectResponse(t *testing.T, cr *CallRequest,
 	}
 }
 
+// CallAndExpectResultEventually calls the method until its result matches the expected one or the timeout expires,
+// it's used when the result depends on the work done in the background, e.g. indexing the workspace folders
+func (lsm *langServerMock) CallAndExpectResultEventually(t *testing.T, cr *CallRequest, expectResult string, timeout time.Duration) {
+	expected := bytes.NewBuffer([]byte{})
+	if err := json.Compact(expected, []byte(expectResult)); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		rsp := lsm.Call(t, cr)
+		actual := bytes.NewBuffer([]byte{})
+		if err := json.Compact(actual, rsp.Result); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(expected.Bytes(), actual.Bytes()) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%q result doesn't match.\n%s", cr.Method, cmp.Diff(expected.String(), actual.String()))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
 func (lsm *langServerMock) CallAndExpectError(t *testing.T, cr *CallRequest, expectErr error) {
 	_, err := lsm.client.Call(context.Background(), cr.Method, json.RawMessage(cr.ReqParams))
 	if err == nil {
@@ -2,16 +2,21 @@ package lsp
 
 import (
 	"sort"
+	"strings"
 
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
 )
 
 const (
-	RefactorRewrite = "refactor.rewrite"
+	RefactorRewrite   = "refactor.rewrite"
+	SourceFixAllAzure = "source.fixAll.azure"
 )
 
 type CodeActions map[lsp.CodeActionKind]bool
 
+// `quickfix`: Quick fixes are attached to the schema validation diagnostics, e.g. replacing
+// an unexpected property with the suggested one, or removing a read only property.
+
 // `source.*`: Source code actions apply to the entire file. They must be explicitly
 // requested and will not show in the normal lightbulb menu. Source actions
 // can be run on save using editor.codeActionsOnSave and are also shown in
@@ -21,15 +26,17 @@ type CodeActions map[lsp.CodeActionKind]bool
 // `source.fixAll`: Fix all actions automatically fix errors that have a clear fix that do
 // not require user input. They should not suppress errors or perform unsafe
 // fixes such as generating new types or classes.
-// ** We don't support this as terraform fmt only adjusts style**
-// lsp.SourceFixAll: true,
+// We only support `source.fixAll.azure`, which applies the schema validation fixes with a single clear answer:
+// removing read only properties, correcting the case of enum values and fixing string literal discriminators.
 
 // `source.formatAll`: Generic format code action.
 // We do not register this for terraform to allow fine grained selection of actions.
 // A user should be able to set `source.formatAll` to true, and source.formatAll.terraform to false to allow all
 // files to be formatted, but not terraform files (or vice versa).
 var SupportedCodeActions = CodeActions{
-	RefactorRewrite: true,
+	lsp.QuickFix:      true,
+	RefactorRewrite:   true,
+	SourceFixAllAzure: true,
 }
 
 func (c CodeActions) AsSlice() []lsp.CodeActionKind {
@@ -44,12 +51,16 @@ func (c CodeActions) AsSlice() []lsp.CodeActionKind {
 	return s
 }
 
+// Only returns the code actions of the given kinds, the kinds are hierarchical,
+// e.g. `source.fixAll` includes `source.fixAll.azure`
 func (ca CodeActions) Only(only []lsp.CodeActionKind) CodeActions {
 	wanted := make(CodeActions)
 
 	for _, kind := range only {
-		if v, ok := ca[kind]; ok {
-			wanted[kind] = v
+		for k, v := range ca {
+			if k == kind || strings.HasPrefix(string(k), string(kind)+".") {
+				wanted[k] = v
+			}
 		}
 	}
 
@@ -0,0 +1,43 @@
+package lsp
+
+import (
+	"testing"
+
+	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCodeActions_Only(t *testing.T) {
+	testcases := []struct {
+		only     []lsp.CodeActionKind
+		expected []lsp.CodeActionKind
+	}{
+		{
+			only:     []lsp.CodeActionKind{lsp.QuickFix},
+			expected: []lsp.CodeActionKind{lsp.QuickFix},
+		},
+		{
+			only:     []lsp.CodeActionKind{lsp.SourceFixAll},
+			expected: []lsp.CodeActionKind{SourceFixAllAzure},
+		},
+		{
+			only:     []lsp.CodeActionKind{lsp.Source},
+			expected: []lsp.CodeActionKind{SourceFixAllAzure},
+		},
+		{
+			only:     []lsp.CodeActionKind{"source.fixAll.terraform"},
+			expected: []lsp.CodeActionKind{},
+		},
+		{
+			only:     []lsp.CodeActionKind{lsp.Refactor, lsp.QuickFix},
+			expected: []lsp.CodeActionKind{lsp.QuickFix, RefactorRewrite},
+		},
+	}
+
+	for _, tc := range testcases {
+		actual := SupportedCodeActions.Only(tc.only).AsSlice()
+		if diff := cmp.Diff(tc.expected, actual); diff != "" {
+			t.Errorf("unexpected code actions for %v: %s", tc.only, diff)
+		}
+	}
+}
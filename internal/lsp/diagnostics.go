@@ -18,6 +18,11 @@ func HCLSeverityToLSP(severity hcl.DiagnosticSeverity) lsp.DiagnosticSeverity {
 	return sev
 }
 
+// diagnosticCoder is implemented by the extra info of the diagnostics which have a code
+type diagnosticCoder interface {
+	DiagnosticCode() string
+}
+
 func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
 	diags := []lsp.Diagnostic{}
 
@@ -30,12 +35,17 @@ func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
 		if hclDiag.Subject != nil {
 			rnge = HCLRangeToLSP(*hclDiag.Subject)
 		}
-		diags = append(diags, lsp.Diagnostic{
+		diag := lsp.Diagnostic{
 			Range:    rnge,
 			Severity: HCLSeverityToLSP(hclDiag.Severity),
 			Source:   source,
 			Message:  msg,
-		})
+		}
+		// the diagnostics of the schema validation carry the code of their rule
+		if extra, ok := hcl.DiagnosticExtra[diagnosticCoder](hclDiag); ok && extra != nil {
+			diag.Code = extra.DiagnosticCode()
+		}
+		diags = append(diags, diag)
 
 	}
 	return diags
@@ -2,8 +2,21 @@ package lsp
 
 import (
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
+	"github.com/hashicorp/hcl-lang/lang"
 )
 
+// Token types of the properties in the body of azapi and msgraph resources,
+// they extend the token types defined in hcl-lang
+const (
+	TokenBodyProperty lang.SemanticTokenType = lang.TokenTypePrimitive + 1 + iota
+	TokenBodyDiscriminator
+	TokenBodyEnumValue
+	TokenBodyUnknownProperty
+)
+
+// TokenModifierBodyReadOnly marks the read-only properties in the body
+const TokenModifierBodyReadOnly = lang.TokenModifierDeprecated + 1
+
 type SemanticTokensClientCapabilities struct {
 	lsp.SemanticTokensClientCapabilities
 }
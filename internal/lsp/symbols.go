@@ -3,16 +3,16 @@ package lsp
 import (
 	"path/filepath"
 
+	"github.com/Azure/ms-terraform-lsp/internal/module"
 	lsp "github.com/Azure/ms-terraform-lsp/internal/protocol"
 	"github.com/Azure/ms-terraform-lsp/internal/uri"
-	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/zclconf/go-cty/cty"
 )
 
-func WorkspaceSymbols(sbs []decoder.Symbol, caps *lsp.WorkspaceSymbolClientCapabilities) []lsp.SymbolInformation {
-	symbols := make([]lsp.SymbolInformation, len(sbs))
-	for i, s := range sbs {
+func WorkspaceSymbols(sbs []module.Symbol, caps *lsp.WorkspaceSymbolClientCapabilities) []lsp.SymbolInformation {
+	symbols := make([]lsp.SymbolInformation, 0, len(sbs))
+	for _, s := range sbs {
 		kind, ok := symbolKind(s, caps.SymbolKind.ValueSet)
 		if !ok {
 			// skip symbol not supported by client
@@ -20,7 +20,7 @@ func WorkspaceSymbols(sbs []decoder.Symbol, caps *lsp.WorkspaceSymbolClientCapab
 		}
 
 		path := filepath.Join(s.Path().Path, s.Range().Filename)
-		symbols[i] = lsp.SymbolInformation{
+		symbol := lsp.SymbolInformation{
 			Name: s.Name(),
 			Kind: kind,
 			Location: lsp.Location{
@@ -28,11 +28,15 @@ func WorkspaceSymbols(sbs []decoder.Symbol, caps *lsp.WorkspaceSymbolClientCapab
 				URI:   lsp.DocumentURI(uri.FromPath(path)),
 			},
 		}
+		if bs, ok := s.(*module.BlockSymbol); ok && bs.Detail != bs.Name() {
+			symbol.ContainerName = bs.Detail
+		}
+		symbols = append(symbols, symbol)
 	}
 	return symbols
 }
 
-func DocumentSymbols(sbs []decoder.Symbol, caps lsp.DocumentSymbolClientCapabilities) []lsp.DocumentSymbol {
+func DocumentSymbols(sbs []module.Symbol, caps lsp.DocumentSymbolClientCapabilities) []lsp.DocumentSymbol {
 	symbols := make([]lsp.DocumentSymbol, 0)
 
 	for _, s := range sbs {
@@ -46,7 +50,7 @@ func DocumentSymbols(sbs []decoder.Symbol, caps lsp.DocumentSymbolClientCapabili
 	return symbols
 }
 
-func documentSymbol(symbol decoder.Symbol, caps lsp.DocumentSymbolClientCapabilities) (lsp.DocumentSymbol, bool) {
+func documentSymbol(symbol module.Symbol, caps lsp.DocumentSymbolClientCapabilities) (lsp.DocumentSymbol, bool) {
 	kind, ok := symbolKind(symbol, caps.SymbolKind.ValueSet)
 	if !ok {
 		return lsp.DocumentSymbol{}, false
@@ -58,29 +62,29 @@ func documentSymbol(symbol decoder.Symbol, caps lsp.DocumentSymbolClientCapabili
 		Range:          HCLRangeToLSP(symbol.Range()),
 		SelectionRange: HCLRangeToLSP(symbol.Range()),
 	}
+	if bs, ok := symbol.(*module.BlockSymbol); ok {
+		ds.Detail = bs.Detail
+	}
 	if caps.HierarchicalDocumentSymbolSupport {
 		ds.Children = DocumentSymbols(symbol.NestedSymbols(), caps)
 	}
 	return ds, true
 }
 
-func symbolKind(symbol decoder.Symbol, supported []lsp.SymbolKind) (lsp.SymbolKind, bool) {
+func symbolKind(symbol module.Symbol, supported []lsp.SymbolKind) (lsp.SymbolKind, bool) {
 	switch s := symbol.(type) {
-	case *decoder.BlockSymbol:
+	case *module.BlockSymbol:
 		kind, ok := supportedSymbolKind(supported, lsp.Class)
 		if ok {
 			return kind, true
 		}
-	case *decoder.AttributeSymbol:
-		kind, ok := exprSymbolKind(s.ExprKind, supported)
-		if ok {
-			return kind, true
-		}
-	case *decoder.ExprSymbol:
+	case *module.PropertySymbol:
 		kind, ok := exprSymbolKind(s.ExprKind, supported)
 		if ok {
 			return kind, true
 		}
+		// fallback to the variable kind, so the nested properties are still listed
+		return supportedSymbolKind(supported, lsp.Variable)
 	}
 
 	return lsp.SymbolKind(0), false
@@ -109,6 +113,10 @@ func exprSymbolKind(symbolKind lang.SymbolExprKind, supported []lsp.SymbolKind)
 }
 
 func supportedSymbolKind(supported []lsp.SymbolKind, kind lsp.SymbolKind) (lsp.SymbolKind, bool) {
+	// the client supports the kinds from File to Array if it doesn't declare the supported kinds
+	if len(supported) == 0 {
+		return kind, lsp.File <= kind && kind <= lsp.Array
+	}
 	for _, s := range supported {
 		if s == kind {
 			return s, true
@@ -51,6 +51,14 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 		tokenType = TokenTypeVariable
 	case lang.TokenTraversalStep:
 		tokenType = TokenTypeVariable
+	case TokenBodyProperty:
+		tokenType = TokenTypeProperty
+	case TokenBodyDiscriminator:
+		tokenType = TokenTypeTypeParameter
+	case TokenBodyEnumValue:
+		tokenType = TokenTypeEnumMember
+	case TokenBodyUnknownProperty:
+		tokenType = TokenTypeVariable
 
 	default:
 		return []uint32{}
@@ -74,6 +82,11 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 				continue
 			}
 			modifiers = append(modifiers, TokenModifierDeprecated)
+		case TokenModifierBodyReadOnly:
+			if !te.tokenModifierSupported(TokenModifierReadonly) {
+				continue
+			}
+			modifiers = append(modifiers, TokenModifierReadonly)
 		}
 	}
 
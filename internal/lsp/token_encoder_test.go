@@ -336,3 +336,67 @@ func TestTokenEncoder_unsupported(t *testing.T) {
 			expectedData, data)
 	}
 }
+
+func TestTokenEncoder_bodyTokens(t *testing.T) {
+	bytes := []byte(`body = {
+  kind = "StorageV2"
+  id = "foo"
+  unknown = true
+}`)
+	te := &TokenEncoder{
+		Lines: source.MakeSourceLines("test.tf", bytes),
+		Tokens: []lang.SemanticToken{
+			{
+				Type: TokenBodyDiscriminator,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
+					End:      hcl.Pos{Line: 2, Column: 7, Byte: 15},
+				},
+			},
+			{
+				Type: TokenBodyEnumValue,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 10, Byte: 18},
+					End:      hcl.Pos{Line: 2, Column: 21, Byte: 29},
+				},
+			},
+			{
+				Type: TokenBodyProperty,
+				Modifiers: []lang.SemanticTokenModifier{
+					TokenModifierBodyReadOnly,
+				},
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 32},
+					End:      hcl.Pos{Line: 3, Column: 5, Byte: 34},
+				},
+			},
+			{
+				Type: TokenBodyUnknownProperty,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 4, Column: 3, Byte: 45},
+					End:      hcl.Pos{Line: 4, Column: 10, Byte: 52},
+				},
+			},
+		},
+		ClientCaps: protocol.SemanticTokensClientCapabilities{
+			TokenTypes:     serverTokenTypes.AsStrings(),
+			TokenModifiers: serverTokenModifiers.AsStrings(),
+		},
+	}
+	data := te.Encode()
+	expectedData := []uint32{
+		1, 2, 4, 8, 0,
+		0, 7, 11, 7, 0,
+		1, 2, 2, 2, 4,
+		1, 2, 7, 6, 0,
+	}
+
+	if diff := cmp.Diff(expectedData, data); diff != "" {
+		t.Fatalf("unexpected encoded data.\nexpected: %#v\ngiven:    %#v",
+			expectedData, data)
+	}
+}
@@ -113,10 +113,13 @@ var (
 		TokenTypeNumber,
 		TokenTypeParameter,
 		TokenTypeVariable,
+		TokenTypeEnumMember,
+		TokenTypeTypeParameter,
 	}
 	serverTokenModifiers = TokenModifiers{
 		TokenModifierDeprecated,
 		TokenModifierModification,
+		TokenModifierReadonly,
 	}
 )
 
@@ -0,0 +1,163 @@
+package module
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+)
+
+// Index keeps the modules of the workspace in memory, so the features which need the whole module don't have to read
+// all the files on each request. The modules are never modified after they're added to the index, an update replaces
+// the module with a new one, so the modules returned by the index can be used without locking.
+type Index struct {
+	fsys FS
+
+	mu      sync.RWMutex
+	modules map[string]*Module
+}
+
+func NewIndex(fsys FS) *Index {
+	return &Index{
+		fsys:    fsys,
+		modules: make(map[string]*Module),
+	}
+}
+
+// IndexFolder loads the modules under the root directory which aren't indexed yet
+func (idx *Index) IndexFolder(root string) error {
+	dirs, err := FindModuleDirs(idx.fsys, root)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if _, err := idx.Module(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Module returns the module in the directory, it's loaded and indexed if it isn't indexed yet
+func (idx *Index) Module(dir string) (*Module, error) {
+	dir = filepath.Clean(dir)
+	idx.mu.RLock()
+	m, ok := idx.modules[dir]
+	idx.mu.RUnlock()
+	if ok {
+		return m, nil
+	}
+
+	loaded, err := LoadModule(idx.fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	// the module may be indexed or updated while it's loaded, the indexed one is newer
+	if m, ok := idx.modules[dir]; ok {
+		return m, nil
+	}
+	idx.modules[dir] = loaded
+	return loaded, nil
+}
+
+// Modules returns the indexed modules under the root directory, ordered by their paths
+func (idx *Index) Modules(root string) []*Module {
+	root = filepath.Clean(root)
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	res := make([]*Module, 0)
+	for dir, m := range idx.modules {
+		if isWithin(dir, root) {
+			res = append(res, m)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+	return res
+}
+
+// SetFile updates the file in the indexed module with the source, e.g. when the document is changed in the client.
+// Nothing happens if the module isn't indexed, it'll be loaded with the file when it's needed.
+func (idx *Index) SetFile(path string, src []byte) {
+	dir, filename := filepath.Split(filepath.Clean(path))
+	if !IsModuleFilename(filename) {
+		return
+	}
+	idx.update(filepath.Clean(dir), func(m *Module) {
+		m.SetFile(filename, src)
+	})
+}
+
+// SetParsedFile is like SetFile, but the file which is already parsed is added without parsing the source again
+func (idx *Index) SetParsedFile(path string, file *parser.File) {
+	dir, filename := filepath.Split(filepath.Clean(path))
+	if !IsModuleFilename(filename) {
+		return
+	}
+	idx.update(filepath.Clean(dir), func(m *Module) {
+		m.SetParsedFile(filename, file.File, file.Src)
+	})
+}
+
+// ReloadFile reads the file again and updates it in the indexed module, the file is removed if it doesn't exist
+func (idx *Index) ReloadFile(path string) error {
+	if !IsModuleFilename(filepath.Base(path)) {
+		return nil
+	}
+	src, err := idx.fsys.ReadFile(path)
+	if err != nil {
+		idx.RemoveFile(path)
+		return err
+	}
+	idx.SetFile(path, src)
+	return nil
+}
+
+// RemoveFile removes the file from the indexed module, the modules under the path are removed if it's a directory
+func (idx *Index) RemoveFile(path string) {
+	path = filepath.Clean(path)
+	if !IsModuleFilename(filepath.Base(path)) {
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		for dir := range idx.modules {
+			if isWithin(dir, path) {
+				delete(idx.modules, dir)
+			}
+		}
+		return
+	}
+	dir, filename := filepath.Split(path)
+	idx.update(filepath.Clean(dir), func(m *Module) {
+		m.RemoveFile(filename)
+	})
+}
+
+// update applies the change to a copy of the indexed module and replaces the module with the copy
+func (idx *Index) update(dir string, change func(m *Module)) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	m, ok := idx.modules[dir]
+	if !ok {
+		return
+	}
+	updated := NewModule(m.Path)
+	for filename := range m.Files {
+		updated.Files[filename] = m.Files[filename]
+		updated.Sources[filename] = m.Sources[filename]
+	}
+	change(updated)
+	idx.modules[dir] = updated
+}
+
+// isWithin returns true if the path is the root directory or in it
+func isWithin(path string, root string) bool {
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
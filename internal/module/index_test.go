@@ -0,0 +1,91 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_Index(t *testing.T) {
+	root := t.TempDir()
+	for file, content := range map[string]string{
+		"main.tf":                 `resource "azapi_resource" "test" {}`,
+		"variables.tf":            `variable "location" {}`,
+		"modules/network/main.tf": `locals { name = "test" }`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	idx := NewIndex(osFS{})
+	if err := idx.IndexFolder(root); err != nil {
+		t.Fatal(err)
+	}
+	modules := idx.Modules(root)
+	if len(modules) != 2 || modules[0].Path != root || modules[1].Path != filepath.Join(root, "modules", "network") {
+		t.Fatalf("expected the root and the network modules, got %v", modules)
+	}
+	indexed := modules[0]
+	if expected := []string{"azapi_resource.test", "var.location"}; !reflect.DeepEqual(declarationAddresses(indexed), expected) {
+		t.Errorf("expected %v, got %v", expected, declarationAddresses(indexed))
+	}
+
+	// the unsaved content replaces the file, the module returned before isn't changed
+	idx.SetFile(filepath.Join(root, "main.tf"), []byte(`resource "azapi_resource" "renamed" {}`))
+	m, err := idx.Module(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"azapi_resource.renamed", "var.location"}; !reflect.DeepEqual(declarationAddresses(m), expected) {
+		t.Errorf("expected %v, got %v", expected, declarationAddresses(m))
+	}
+	if expected := []string{"azapi_resource.test", "var.location"}; !reflect.DeepEqual(declarationAddresses(indexed), expected) {
+		t.Errorf("expected the previous module isn't changed, got %v", declarationAddresses(indexed))
+	}
+
+	// the file on the disk is read again
+	if err := idx.ReloadFile(filepath.Join(root, "main.tf")); err != nil {
+		t.Fatal(err)
+	}
+	m, _ = idx.Module(root)
+	if expected := []string{"azapi_resource.test", "var.location"}; !reflect.DeepEqual(declarationAddresses(m), expected) {
+		t.Errorf("expected %v, got %v", expected, declarationAddresses(m))
+	}
+
+	// a new file is added to the module
+	if err := os.WriteFile(filepath.Join(root, "locals.tf"), []byte(`locals { prefix = "test" }`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.ReloadFile(filepath.Join(root, "locals.tf")); err != nil {
+		t.Fatal(err)
+	}
+	m, _ = idx.Module(root)
+	if expected := []string{"local.prefix", "azapi_resource.test", "var.location"}; !reflect.DeepEqual(declarationAddresses(m), expected) {
+		t.Errorf("expected %v, got %v", expected, declarationAddresses(m))
+	}
+
+	// the deleted file is removed, and the modules in the deleted directory are removed
+	idx.RemoveFile(filepath.Join(root, "variables.tf"))
+	m, _ = idx.Module(root)
+	if expected := []string{"local.prefix", "azapi_resource.test"}; !reflect.DeepEqual(declarationAddresses(m), expected) {
+		t.Errorf("expected %v, got %v", expected, declarationAddresses(m))
+	}
+	idx.RemoveFile(filepath.Join(root, "modules"))
+	if modules := idx.Modules(root); len(modules) != 1 {
+		t.Errorf("expected only the root module, got %v", modules)
+	}
+}
+
+func declarationAddresses(m *Module) []string {
+	res := make([]string, 0)
+	for _, declaration := range m.Declarations() {
+		res = append(res, declaration.Address)
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package module
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// FS is the subset of the filesystem which is needed to load a module,
+// it's satisfied by filesystem.Filesystem which also reads unsaved documents
+type FS interface {
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+}
+
+// Module represents the terraform configuration files which live in the same directory
+type Module struct {
+	Path    string
+	Files   map[string]*hcl.File
+	Sources map[string][]byte
+}
+
+func NewModule(path string) *Module {
+	return &Module{
+		Path:    path,
+		Files:   make(map[string]*hcl.File),
+		Sources: make(map[string][]byte),
+	}
+}
+
+// LoadModule parses all the .tf files in the given directory
+func LoadModule(fsys FS, dir string) (*Module, error) {
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading module %q: %w", dir, err)
+	}
+
+	m := NewModule(dir)
+	for _, entry := range entries {
+		if entry.IsDir() || !IsModuleFilename(entry.Name()) {
+			continue
+		}
+		src, err := fsys.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading file %q: %w", entry.Name(), err)
+		}
+		m.SetFile(entry.Name(), src)
+	}
+	return m, nil
+}
+
+// IsModuleFilename returns true if the file is a terraform configuration file
+func IsModuleFilename(name string) bool {
+	return filepath.Ext(name) == ".tf"
+}
+
+// SetFile parses the source and adds it to the module, it replaces the file with the same name
+func (m *Module) SetFile(filename string, src []byte) {
+	file, _ := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	m.SetParsedFile(filename, file, src)
+}
+
+// SetParsedFile adds the file parsed from the source to the module, it replaces the file with the same name
+func (m *Module) SetParsedFile(filename string, file *hcl.File, src []byte) {
+	m.Files[filename] = file
+	m.Sources[filename] = src
+}
+
+// RemoveFile removes the file from the module
+func (m *Module) RemoveFile(filename string) {
+	delete(m.Files, filename)
+	delete(m.Sources, filename)
+}
+
+// Filenames returns the sorted file names of the module
+func (m *Module) Filenames() []string {
+	names := make([]string, 0, len(m.Files))
+	for name := range m.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Body returns the syntax body of the file, or nil if the file doesn't exist
+func (m *Module) Body(filename string) *hclsyntax.Body {
+	file, ok := m.Files[filename]
+	if !ok || file == nil {
+		return nil
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	return body
+}
+
+// FindModuleDirs returns the directories under the root which contain .tf files,
+// the hidden directories like .terraform and .git are skipped
+func FindModuleDirs(fsys FS, root string) ([]string, error) {
+	entries, err := fsys.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %q: %w", root, err)
+	}
+
+	dirs := make([]string, 0)
+	isModule := false
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			isModule = isModule || IsModuleFilename(entry.Name())
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		nested, err := FindModuleDirs(fsys, filepath.Join(root, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, nested...)
+	}
+	if isModule {
+		dirs = append([]string{root}, dirs...)
+	}
+	return dirs, nil
+}
@@ -0,0 +1,45 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type osFS struct{}
+
+func (osFS) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func Test_FindModuleDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"main.tf",
+		"modules/network/main.tf",
+		"modules/empty/README.md",
+		".terraform/modules/remote/main.tf",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte{}, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dirs, err := FindModuleDirs(osFS{}, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{root, filepath.Join(root, "modules", "network")}
+	if !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("expected %v, got %v", expected, dirs)
+	}
+}
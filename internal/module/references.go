@@ -0,0 +1,293 @@
+package module
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Declaration is an object which can be referenced in the module, e.g. a resource, a data source, a variable or a local value
+type Declaration struct {
+	// Address is the address used to reference the object, e.g. azapi_resource.example, data.azurerm_client_config.current, var.location
+	Address string
+	// Range is the range of the whole declaration
+	Range hcl.Range
+	// DefRange is the range of the block header or the local value's name
+	DefRange hcl.Range
+	// Block is the declaring block, it's nil for local values
+	Block *hclsyntax.Block
+}
+
+// Reference is a traversal which refers to a declaration, e.g. azapi_resource.example.id
+type Reference struct {
+	// Address is the address of the referenced object
+	Address string
+	// Range is the range of the address part in the traversal
+	Range hcl.Range
+	// NameRange is the range of the referenced object's name, e.g. example in azapi_resource.example.id
+	NameRange hcl.Range
+}
+
+// Declarations returns all the declarations in the module
+func (m *Module) Declarations() []Declaration {
+	res := make([]Declaration, 0)
+	for _, filename := range m.Filenames() {
+		res = append(res, m.FileDeclarations(filename)...)
+	}
+	return res
+}
+
+// FileDeclarations returns the declarations in the given file
+func (m *Module) FileDeclarations(filename string) []Declaration {
+	body := m.Body(filename)
+	if body == nil {
+		return nil
+	}
+
+	res := make([]Declaration, 0)
+	for _, block := range body.Blocks {
+		if block.Type == "locals" {
+			for _, attr := range sortedAttributes(block.Body.Attributes) {
+				res = append(res, Declaration{
+					Address:  "local." + attr.Name,
+					Range:    attr.SrcRange,
+					DefRange: attr.NameRange,
+				})
+			}
+			continue
+		}
+
+		address := BlockAddress(block)
+		if address == "" {
+			continue
+		}
+		res = append(res, Declaration{
+			Address:  address,
+			Range:    block.Range(),
+			DefRange: block.DefRange(),
+			Block:    block,
+		})
+	}
+	return res
+}
+
+// BlockAddress returns the address used to reference the block, or an empty string if the block can't be referenced
+func BlockAddress(block *hclsyntax.Block) string {
+	switch block.Type {
+	case "resource":
+		if len(block.Labels) == 2 {
+			return block.Labels[0] + "." + block.Labels[1]
+		}
+	case "data", "ephemeral":
+		if len(block.Labels) == 2 {
+			return block.Type + "." + block.Labels[0] + "." + block.Labels[1]
+		}
+	case "module":
+		if len(block.Labels) == 1 {
+			return "module." + block.Labels[0]
+		}
+	case "variable":
+		if len(block.Labels) == 1 {
+			return "var." + block.Labels[0]
+		}
+	}
+	return ""
+}
+
+// References returns all the references in the module
+func (m *Module) References() []Reference {
+	res := make([]Reference, 0)
+	for _, filename := range m.Filenames() {
+		res = append(res, m.FileReferences(filename)...)
+	}
+	return res
+}
+
+// FileReferences returns the references in the given file, ordered by their positions
+func (m *Module) FileReferences(filename string) []Reference {
+	body := m.Body(filename)
+	if body == nil {
+		return nil
+	}
+
+	res := make([]Reference, 0)
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+		if ref := TraversalReference(expr.Traversal); ref != nil {
+			res = append(res, *ref)
+		}
+		return nil
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Range.Start.Byte < res[j].Range.Start.Byte
+	})
+	return res
+}
+
+// TraversalReference returns the reference made by the traversal, or nil if it doesn't refer to any declaration
+func TraversalReference(traversal hcl.Traversal) *Reference {
+	if len(traversal) == 0 {
+		return nil
+	}
+
+	length := 2
+	switch traversal.RootName() {
+	case "data", "ephemeral":
+		length = 3
+	case "var", "local", "module":
+		length = 2
+	case "count", "each", "self", "path", "terraform":
+		return nil
+	default:
+		// resource types are always prefixed with the provider name, e.g. azapi_resource
+		if !strings.Contains(traversal.RootName(), "_") {
+			return nil
+		}
+	}
+
+	if len(traversal) < length {
+		return nil
+	}
+
+	names := []string{traversal.RootName()}
+	for _, step := range traversal[1:length] {
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			return nil
+		}
+		names = append(names, attr.Name)
+	}
+
+	// the range of the attribute step includes the leading dot
+	nameRange := traversal[length-1].SourceRange()
+	nameRange.Start.Column++
+	nameRange.Start.Byte++
+
+	return &Reference{
+		Address:   strings.Join(names, "."),
+		Range:     hcl.RangeBetween(traversal[0].SourceRange(), traversal[length-1].SourceRange()),
+		NameRange: nameRange,
+	}
+}
+
+// ReferenceAtPos returns the reference at the given position, or nil if there's no reference at the position
+func (m *Module) ReferenceAtPos(filename string, pos hcl.Pos) *Reference {
+	for _, ref := range m.FileReferences(filename) {
+		if parser.ContainsPos(ref.Range, pos) {
+			return &ref
+		}
+	}
+	return nil
+}
+
+// DeclarationsByAddress returns the declarations with the given address
+func (m *Module) DeclarationsByAddress(address string) []Declaration {
+	res := make([]Declaration, 0)
+	for _, declaration := range m.Declarations() {
+		if declaration.Address == address {
+			res = append(res, declaration)
+		}
+	}
+	return res
+}
+
+// ReferenceTargetsForOriginAtPos returns the declarations referenced by the traversal at the given position
+func (m *Module) ReferenceTargetsForOriginAtPos(filename string, pos hcl.Pos) decoder.ReferenceTargets {
+	targets := make(decoder.ReferenceTargets, 0)
+
+	ref := m.ReferenceAtPos(filename, pos)
+	if ref == nil {
+		return targets
+	}
+
+	for _, declaration := range m.DeclarationsByAddress(ref.Address) {
+		defRange := declaration.DefRange
+		targets = append(targets, &decoder.ReferenceTarget{
+			OriginRange: ref.Range,
+			Path:        m.langPath(),
+			Range:       declaration.Range,
+			DefRangePtr: &defRange,
+		})
+	}
+	return targets
+}
+
+// AddressAtPos returns the address of the reference or the declaration at the given position,
+// or an empty string if there's neither a reference nor a declaration at the position
+func (m *Module) AddressAtPos(filename string, pos hcl.Pos) string {
+	if ref := m.ReferenceAtPos(filename, pos); ref != nil {
+		return ref.Address
+	}
+	for _, declaration := range m.FileDeclarations(filename) {
+		if parser.ContainsPos(declaration.DefRange, pos) {
+			return declaration.Address
+		}
+	}
+	return ""
+}
+
+// ReferencesByAddress returns the references to the given address
+func (m *Module) ReferencesByAddress(address string) []Reference {
+	res := make([]Reference, 0)
+	for _, ref := range m.References() {
+		if ref.Address == address {
+			res = append(res, ref)
+		}
+	}
+	return res
+}
+
+// ReferenceOriginsTargetingPos returns the references to the object which is declared or referenced at the given position,
+// the declarations of the object are included at the beginning if includeDeclaration is true
+func (m *Module) ReferenceOriginsTargetingPos(filename string, pos hcl.Pos, includeDeclaration bool) decoder.ReferenceOrigins {
+	origins := make(decoder.ReferenceOrigins, 0)
+
+	address := m.AddressAtPos(filename, pos)
+	if address == "" {
+		return origins
+	}
+
+	if includeDeclaration {
+		for _, declaration := range m.DeclarationsByAddress(address) {
+			origins = append(origins, decoder.ReferenceOrigin{
+				Path:  m.langPath(),
+				Range: declaration.DefRange,
+			})
+		}
+	}
+
+	for _, ref := range m.ReferencesByAddress(address) {
+		origins = append(origins, decoder.ReferenceOrigin{
+			Path:  m.langPath(),
+			Range: ref.Range,
+		})
+	}
+	return origins
+}
+
+func (m *Module) langPath() lang.Path {
+	return lang.Path{
+		Path:       m.Path,
+		LanguageID: "terraform",
+	}
+}
+
+// sortedAttributes returns the attributes in the order they're defined
+func sortedAttributes(attributes hclsyntax.Attributes) []*hclsyntax.Attribute {
+	res := make([]*hclsyntax.Attribute, 0, len(attributes))
+	for _, attr := range attributes {
+		res = append(res, attr)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].SrcRange.Start.Byte < res[j].SrcRange.Start.Byte
+	})
+	return res
+}
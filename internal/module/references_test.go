@@ -0,0 +1,141 @@
+package module
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func Test_ReferenceTargetsForOriginAtPos(t *testing.T) {
+	m := NewModule("/tmp/module")
+	m.SetFile("main.tf", []byte(`resource "azapi_resource" "subnet" {
+  type      = "Microsoft.Network/virtualNetworks/subnets@2024-01-01"
+  parent_id = azurerm_virtual_network.vnet.id
+  name      = "${var.prefix}-subnet"
+  body = {
+    properties = {
+      addressPrefix = local.prefix
+    }
+  }
+}
+
+output "subnet_id" {
+  value = azapi_resource.subnet.output.id
+}
+`))
+	m.SetFile("network.tf", []byte(`variable "prefix" {
+  type = string
+}
+
+locals {
+  prefix = "10.0.0.0/24"
+}
+
+resource "azurerm_virtual_network" "vnet" {
+  name = var.prefix
+}
+`))
+
+	testcases := []struct {
+		Name     string
+		Pos      hcl.Pos
+		Expected []string
+	}{
+		{
+			Name:     "azurerm resource in another file",
+			Pos:      hcl.Pos{Line: 3, Column: 20},
+			Expected: []string{"resource \"azurerm_virtual_network\" \"vnet\""},
+		},
+		{
+			Name:     "variable in template",
+			Pos:      hcl.Pos{Line: 4, Column: 20},
+			Expected: []string{"variable \"prefix\""},
+		},
+		{
+			Name:     "local value in body",
+			Pos:      hcl.Pos{Line: 7, Column: 25},
+			Expected: []string{"prefix"},
+		},
+		{
+			Name:     "azapi output",
+			Pos:      hcl.Pos{Line: 13, Column: 13},
+			Expected: []string{"resource \"azapi_resource\" \"subnet\""},
+		},
+		{
+			Name:     "not a reference",
+			Pos:      hcl.Pos{Line: 2, Column: 5},
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		targets := m.ReferenceTargetsForOriginAtPos("main.tf", tc.Pos)
+		actual := make([]string, 0)
+		for _, target := range targets {
+			src := m.Sources[target.Range.Filename]
+			actual = append(actual, string(target.DefRangePtr.SliceBytes(src)))
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Errorf("%s: expected %v, got %v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_ReferenceOriginsTargetingPos(t *testing.T) {
+	m := NewModule("/tmp/module")
+	m.SetFile("main.tf", []byte(`resource "azurerm_resource_group" "rg" {
+  name     = "example"
+  location = "westus"
+}
+
+resource "azurerm_key_vault" "kv" {
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+}
+`))
+	m.SetFile("vnet.tf", []byte(`resource "azapi_resource" "vnet" {
+  parent_id = azurerm_resource_group.rg.id
+}
+`))
+
+	testcases := []struct {
+		Name               string
+		Filename           string
+		Pos                hcl.Pos
+		IncludeDeclaration bool
+		Expected           []string
+	}{
+		{
+			Name:               "from declaration",
+			Filename:           "main.tf",
+			Pos:                hcl.Pos{Line: 1, Column: 37},
+			IncludeDeclaration: true,
+			Expected:           []string{"main.tf:1", "main.tf:7", "main.tf:8", "vnet.tf:2"},
+		},
+		{
+			Name:     "from reference",
+			Filename: "vnet.tf",
+			Pos:      hcl.Pos{Line: 2, Column: 20},
+			Expected: []string{"main.tf:7", "main.tf:8", "vnet.tf:2"},
+		},
+		{
+			Name:     "declaration without references",
+			Filename: "main.tf",
+			Pos:      hcl.Pos{Line: 6, Column: 3},
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		origins := m.ReferenceOriginsTargetingPos(tc.Filename, tc.Pos, tc.IncludeDeclaration)
+		actual := make([]string, 0)
+		for _, origin := range origins {
+			actual = append(actual, fmt.Sprintf("%s:%d", origin.Range.Filename, origin.Range.Start.Line))
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Errorf("%s: expected %v, got %v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
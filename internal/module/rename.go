@@ -0,0 +1,157 @@
+package module
+
+import (
+	"fmt"
+
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// TextEdit is a change to the source of a module file
+type TextEdit struct {
+	Range   hcl.Range
+	NewText string
+}
+
+// RenameTarget is a resource which can be renamed
+type RenameTarget struct {
+	// Declaration is the declaration of the resource
+	Declaration Declaration
+	// Range is the range of the resource name at the requested position, it excludes the quotes of the label
+	Range hcl.Range
+}
+
+// RenameTargetAtPos returns the resource whose name is at the given position, it's either the name label of a
+// resource block or the name part of a reference to a resource. It returns nil if the position is not on a resource name.
+func (m *Module) RenameTargetAtPos(filename string, pos hcl.Pos) *RenameTarget {
+	for _, declaration := range m.FileDeclarations(filename) {
+		block := declaration.Block
+		if block == nil || block.Type != "resource" || len(block.LabelRanges) != 2 {
+			continue
+		}
+		if parser.ContainsPos(block.LabelRanges[1], pos) {
+			return &RenameTarget{
+				Declaration: declaration,
+				Range:       m.labelNameRange(block.LabelRanges[1]),
+			}
+		}
+	}
+
+	ref := m.ReferenceAtPos(filename, pos)
+	if ref == nil || !parser.ContainsPos(ref.NameRange, pos) {
+		return nil
+	}
+	for _, declaration := range m.DeclarationsByAddress(ref.Address) {
+		if declaration.Block != nil && declaration.Block.Type == "resource" {
+			return &RenameTarget{
+				Declaration: declaration,
+				Range:       ref.NameRange,
+			}
+		}
+	}
+	return nil
+}
+
+// RenameResource returns the edits grouped by file name to rename the resource at the given position.
+// All the references in the module are updated and a moved block is added after the resource block,
+// so the resource is not destroyed and recreated by terraform. The references in the existing moved blocks are kept,
+// because they describe the previous addresses of the resource.
+func (m *Module) RenameResource(filename string, pos hcl.Pos, newName string) (map[string][]TextEdit, error) {
+	target := m.RenameTargetAtPos(filename, pos)
+	if target == nil {
+		return nil, fmt.Errorf("there's no resource to rename at the position")
+	}
+
+	if !hclsyntax.ValidIdentifier(newName) {
+		return nil, fmt.Errorf("%q is not a valid resource name", newName)
+	}
+
+	block := target.Declaration.Block
+	resourceType, oldName := block.Labels[0], block.Labels[1]
+	if newName == oldName {
+		return map[string][]TextEdit{}, nil
+	}
+	newAddress := resourceType + "." + newName
+	if len(m.DeclarationsByAddress(newAddress)) != 0 {
+		return nil, fmt.Errorf("resource %s already exists", newAddress)
+	}
+
+	edits := make(map[string][]TextEdit)
+	declFilename := block.Range().Filename
+	edits[declFilename] = append(edits[declFilename], TextEdit{
+		Range:   m.labelNameRange(block.LabelRanges[1]),
+		NewText: newName,
+	})
+
+	for _, name := range m.Filenames() {
+		movedBlockRanges := make([]hcl.Range, 0)
+		if body := m.Body(name); body != nil {
+			for _, b := range body.Blocks {
+				if b.Type == "moved" {
+					movedBlockRanges = append(movedBlockRanges, b.Range())
+				}
+			}
+		}
+
+		for _, ref := range m.FileReferences(name) {
+			if ref.Address != target.Declaration.Address || containsRange(movedBlockRanges, ref.Range) {
+				continue
+			}
+			edits[name] = append(edits[name], TextEdit{
+				Range:   ref.NameRange,
+				NewText: newName,
+			})
+		}
+	}
+
+	end := block.Range().End
+	edits[declFilename] = append(edits[declFilename], TextEdit{
+		Range: hcl.Range{
+			Filename: declFilename,
+			Start:    end,
+			End:      end,
+		},
+		NewText: "\n\n" + string(movedBlock(resourceType, oldName, newName)),
+	})
+
+	return edits, nil
+}
+
+// movedBlock returns the formatted moved block, the trailing newline is trimmed
+func movedBlock(resourceType string, from string, to string) []byte {
+	block := hclwrite.NewBlock("moved", nil)
+	block.Body().SetAttributeTraversal("from", hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: from}})
+	block.Body().SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: to}})
+
+	file := hclwrite.NewEmptyFile()
+	file.Body().AppendBlock(block)
+	out := hclwrite.Format(file.Bytes())
+	for len(out) > 0 && out[len(out)-1] == '\n' {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// labelNameRange returns the range of the label without the quotes
+func (m *Module) labelNameRange(r hcl.Range) hcl.Range {
+	src := m.Sources[r.Filename]
+	if r.Start.Byte >= len(src) || src[r.Start.Byte] != '"' {
+		return r
+	}
+	r.Start.Column++
+	r.Start.Byte++
+	r.End.Column--
+	r.End.Byte--
+	return r
+}
+
+func containsRange(ranges []hcl.Range, r hcl.Range) bool {
+	for _, item := range ranges {
+		if parser.ContainsPos(item, r.Start) && parser.ContainsPos(item, r.End) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package module
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func Test_RenameResource(t *testing.T) {
+	m := NewModule("/tmp/module")
+	m.SetFile("main.tf", []byte(`resource "azapi_resource" "rg" {
+  type = "Microsoft.Resources/resourceGroups@2021-04-01"
+  name = "example"
+}
+
+moved {
+  from = azapi_resource.old
+  to   = azapi_resource.rg
+}
+`))
+	m.SetFile("vnet.tf", []byte(`resource "azapi_resource" "vnet" {
+  parent_id  = azapi_resource.rg.id
+  depends_on = [azapi_resource.rg]
+}
+`))
+
+	edits, err := m.RenameResource("vnet.tf", hcl.Pos{Line: 2, Column: 32}, "resource_group")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"main.tf": `resource "azapi_resource" "resource_group" {
+  type = "Microsoft.Resources/resourceGroups@2021-04-01"
+  name = "example"
+}
+
+moved {
+  from = azapi_resource.rg
+  to   = azapi_resource.resource_group
+}
+
+moved {
+  from = azapi_resource.old
+  to   = azapi_resource.rg
+}
+`,
+		"vnet.tf": `resource "azapi_resource" "vnet" {
+  parent_id  = azapi_resource.resource_group.id
+  depends_on = [azapi_resource.resource_group]
+}
+`,
+	}
+	for filename, content := range expected {
+		actual := applyEdits(m.Sources[filename], edits[filename])
+		if actual != content {
+			t.Errorf("%s: expected\n%s\ngot\n%s", filename, content, actual)
+		}
+	}
+}
+
+func Test_RenameResource_invalid(t *testing.T) {
+	m := NewModule("/tmp/module")
+	m.SetFile("main.tf", []byte(`resource "azapi_resource" "a" {
+}
+
+resource "azapi_resource" "b" {
+}
+
+data "azapi_client_config" "current" {
+}
+`))
+
+	testcases := []struct {
+		Name    string
+		Pos     hcl.Pos
+		NewName string
+	}{
+		{
+			Name:    "address already exists",
+			Pos:     hcl.Pos{Line: 1, Column: 28},
+			NewName: "b",
+		},
+		{
+			Name:    "invalid name",
+			Pos:     hcl.Pos{Line: 1, Column: 28},
+			NewName: "1a",
+		},
+		{
+			Name:    "data source",
+			Pos:     hcl.Pos{Line: 7, Column: 31},
+			NewName: "client",
+		},
+		{
+			Name:    "resource type",
+			Pos:     hcl.Pos{Line: 1, Column: 14},
+			NewName: "c",
+		},
+	}
+
+	for _, tc := range testcases {
+		if _, err := m.RenameResource("main.tf", tc.Pos, tc.NewName); err == nil {
+			t.Errorf("%s: expected an error", tc.Name)
+		}
+	}
+}
+
+func applyEdits(src []byte, edits []TextEdit) string {
+	sorted := make([]TextEdit, len(edits))
+	copy(sorted, edits)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte > sorted[j].Range.Start.Byte
+	})
+	out := string(src)
+	for _, edit := range sorted {
+		out = out[:edit.Range.Start.Byte] + edit.NewText + out[edit.Range.End.Byte:]
+	}
+	return out
+}
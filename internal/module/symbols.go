@@ -0,0 +1,219 @@
+package module
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/ms-terraform-lsp/internal/parser"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Symbol represents a block or a property in the module, it mirrors decoder.Symbol
+// whose implementations can't be built outside of hcl-lang
+type Symbol interface {
+	Path() lang.Path
+	Name() string
+	NestedSymbols() []Symbol
+	Range() hcl.Range
+}
+
+// BlockSymbol is Symbol implementation representing a block
+type BlockSymbol struct {
+	Type   string
+	Labels []string
+
+	// Detail is the address of the top-level block, e.g. azapi_resource.example
+	Detail string
+
+	name          string
+	path          lang.Path
+	rng           hcl.Range
+	nestedSymbols []Symbol
+}
+
+func (bs *BlockSymbol) Name() string {
+	return bs.name
+}
+
+func (bs *BlockSymbol) NestedSymbols() []Symbol {
+	return bs.nestedSymbols
+}
+
+func (bs *BlockSymbol) Range() hcl.Range {
+	return bs.rng
+}
+
+func (bs *BlockSymbol) Path() lang.Path {
+	return bs.path
+}
+
+// PropertySymbol is Symbol implementation representing an attribute or a property of the body
+type PropertySymbol struct {
+	ExprKind lang.SymbolExprKind
+
+	name          string
+	path          lang.Path
+	rng           hcl.Range
+	nestedSymbols []Symbol
+}
+
+func (ps *PropertySymbol) Name() string {
+	return ps.name
+}
+
+func (ps *PropertySymbol) NestedSymbols() []Symbol {
+	return ps.nestedSymbols
+}
+
+func (ps *PropertySymbol) Range() hcl.Range {
+	return ps.rng
+}
+
+func (ps *PropertySymbol) Path() lang.Path {
+	return ps.path
+}
+
+// FileSymbols returns the symbols of the top-level blocks in the given file
+func (m *Module) FileSymbols(filename string) []Symbol {
+	body := m.Body(filename)
+	if body == nil {
+		return nil
+	}
+
+	symbols := make([]Symbol, 0)
+	for _, block := range body.Blocks {
+		symbols = append(symbols, &BlockSymbol{
+			Type:          block.Type,
+			Labels:        block.Labels,
+			Detail:        BlockAddress(block),
+			name:          m.blockSymbolName(block),
+			path:          m.langPath(),
+			rng:           block.Range(),
+			nestedSymbols: m.bodySymbols(block.Body),
+		})
+	}
+	return symbols
+}
+
+// blockSymbolName returns the ARM type for azapi resources, the url for msgraph resources,
+// and the address for the other blocks
+func (m *Module) blockSymbolName(block *hclsyntax.Block) string {
+	if len(block.Labels) == 2 {
+		switch {
+		case strings.HasPrefix(block.Labels[0], "azapi_"):
+			if v := parser.ExtractAzureResourceType(block); v != nil && *v != "" {
+				return *v
+			}
+		case strings.HasPrefix(block.Labels[0], "msgraph_"):
+			if url := parser.ExtractMSGraphUrl(block, m.Sources[block.Range().Filename]); url != "" {
+				return url
+			}
+		}
+	}
+
+	if address := BlockAddress(block); address != "" {
+		return address
+	}
+	return strings.Join(append([]string{block.Type}, block.Labels...), ".")
+}
+
+func (m *Module) bodySymbols(body *hclsyntax.Body) []Symbol {
+	symbols := make([]Symbol, 0)
+	for _, attr := range sortedAttributes(body.Attributes) {
+		symbol := &PropertySymbol{
+			ExprKind: exprSymbolKind(attr.Expr),
+			name:     attr.Name,
+			path:     m.langPath(),
+			rng:      attr.SrcRange,
+		}
+		if attr.Name == "body" || attr.Name == "sensitive_body" {
+			symbol.ExprKind = lang.ObjectConsExprKind{}
+			if hclNode := parser.ExpressionToHclNode(m.Sources[attr.SrcRange.Filename], attr.Expr); hclNode != nil {
+				if dummy, ok := hclNode.Children["dummy"]; ok {
+					symbol.nestedSymbols = m.hclNodeSymbols(dummy, attr.SrcRange.Filename)
+				}
+			}
+		}
+		symbols = append(symbols, symbol)
+	}
+	for _, block := range body.Blocks {
+		symbols = append(symbols, &BlockSymbol{
+			Type:          block.Type,
+			Labels:        block.Labels,
+			name:          strings.Join(append([]string{block.Type}, block.Labels...), "."),
+			path:          m.langPath(),
+			rng:           block.Range(),
+			nestedSymbols: m.bodySymbols(block.Body),
+		})
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].Range().Start.Byte < symbols[j].Range().Start.Byte
+	})
+	return symbols
+}
+
+func (m *Module) hclNodeSymbols(hclNode *parser.HclNode, filename string) []Symbol {
+	symbols := make([]Symbol, 0)
+	for _, child := range hclNode.Children {
+		rng := child.GetRange()
+		rng.Filename = filename
+
+		symbol := &PropertySymbol{
+			name: strings.TrimPrefix(child.Key, hclNode.Key+"."),
+			path: m.langPath(),
+			rng:  rng,
+		}
+		switch {
+		case child.Value != nil:
+			symbol.ExprKind = literalSymbolKind(m.Sources[filename], child)
+		case child.IsValueArray() && len(child.Children) != 0:
+			symbol.ExprKind = lang.TupleConsExprKind{}
+		default:
+			symbol.ExprKind = lang.ObjectConsExprKind{}
+		}
+		if child.Children != nil {
+			symbol.nestedSymbols = m.hclNodeSymbols(child, filename)
+		}
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].Range().Start.Byte < symbols[j].Range().Start.Byte
+	})
+	return symbols
+}
+
+func exprSymbolKind(expr hclsyntax.Expression) lang.SymbolExprKind {
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		return lang.LiteralTypeKind{Type: e.Val.Type()}
+	case *hclsyntax.TemplateExpr:
+		if e.IsStringLiteral() {
+			return lang.LiteralTypeKind{Type: cty.String}
+		}
+	case *hclsyntax.ScopeTraversalExpr:
+		return lang.TraversalExprKind{}
+	case *hclsyntax.TupleConsExpr:
+		return lang.TupleConsExprKind{}
+	case *hclsyntax.ObjectConsExpr:
+		return lang.ObjectConsExprKind{}
+	}
+	return nil
+}
+
+func literalSymbolKind(src []byte, hclNode *parser.HclNode) lang.SymbolExprKind {
+	if start := hclNode.ValueRange.Start.Byte; start < len(src) && src[start] == '"' {
+		return lang.LiteralTypeKind{Type: cty.String}
+	}
+	value := *hclNode.Value
+	if value == "true" || value == "false" {
+		return lang.LiteralTypeKind{Type: cty.Bool}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return lang.LiteralTypeKind{Type: cty.Number}
+	}
+	return lang.LiteralTypeKind{Type: cty.String}
+}
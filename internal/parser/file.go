@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// File is a parsed configuration file, the hcl nodes of the attributes are built on demand and kept with it.
+// It's shared by the requests on the same version of a document, so the syntax tree and the hcl nodes must not be
+// modified.
+type File struct {
+	Src      []byte
+	Filename string
+	File     *hcl.File
+	// Body is nil if the file isn't in the native syntax
+	Body *hclsyntax.Body
+
+	mu    sync.Mutex
+	nodes map[attributeNodeKey]*HclNode
+}
+
+type attributeNodeKey struct {
+	start, end  int
+	jsonEncoded bool
+}
+
+func ParseFile(src []byte, filename string) *File {
+	file, _ := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	body, _ := file.Body.(*hclsyntax.Body)
+	return &File{
+		Src:      src,
+		Filename: filename,
+		File:     file,
+		Body:     body,
+		nodes:    make(map[attributeNodeKey]*HclNode),
+	}
+}
+
+// AttributeHclNode returns the hcl node built from the value of the attribute, the value is stored in the dummy child.
+// The argument of jsonencode is used if jsonEncoded is true and the value is a jsonencode call.
+// The node is built once for each attribute in the file.
+func (f *File) AttributeHclNode(attribute *hclsyntax.Attribute, jsonEncoded bool) *HclNode {
+	r := attribute.Expr.Range()
+	if r.End.Byte > len(f.Src) {
+		return nil
+	}
+	key := attributeNodeKey{start: r.Start.Byte, end: r.End.Byte, jsonEncoded: jsonEncoded}
+
+	f.mu.Lock()
+	hclNode, ok := f.nodes[key]
+	f.mu.Unlock()
+	if ok {
+		return hclNode
+	}
+
+	if jsonEncoded {
+		hclNode = JsonEncodeExpressionToHclNode(f.Src, attribute.Expr)
+	}
+	if hclNode == nil {
+		tokens, _ := hclsyntax.LexExpression(f.Src[r.Start.Byte:r.End.Byte], "", r.Start)
+		hclNode = BuildHclNode(tokens)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nodes[key] = hclNode
+	return hclNode
+}
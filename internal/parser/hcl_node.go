@@ -427,12 +427,18 @@ func CombineHclNodes(a, b *HclNode) *HclNode {
 	if b == nil {
 		return a
 	}
+	// the nodes may be shared, so the children are combined in a copy of a
+	res := *a
+	res.Children = make(map[string]*HclNode, len(a.Children)+len(b.Children))
+	for k, v := range a.Children {
+		res.Children[k] = v
+	}
 	for k, v := range b.Children {
-		if _, ok := a.Children[k]; !ok {
-			a.Children[k] = v
+		if _, ok := res.Children[k]; !ok {
+			res.Children[k] = v
 		} else {
-			a.Children[k] = CombineHclNodes(a.Children[k], v)
+			res.Children[k] = CombineHclNodes(res.Children[k], v)
 		}
 	}
-	return a
+	return &res
 }
@@ -34,6 +34,20 @@ func JsonEncodeExpressionToHclNode(data []byte, expression hclsyntax.Expression)
 	return BuildHclNode(tokens)
 }
 
+// ExpressionToHclNode builds the HclNode from the expression, the expression could be either
+// a jsonencode function call or an HCL object
+func ExpressionToHclNode(data []byte, expression hclsyntax.Expression) *HclNode {
+	if hclNode := JsonEncodeExpressionToHclNode(data, expression); hclNode != nil {
+		return hclNode
+	}
+	r := expression.Range()
+	if r.End.Byte > len(data) {
+		return nil
+	}
+	tokens, _ := hclsyntax.LexExpression(data[r.Start.Byte:r.End.Byte], r.Filename, r.Start)
+	return BuildHclNode(tokens)
+}
+
 func rangeOfJsonEncodeBody(expression hclsyntax.Expression) (*hcl.Range, error) {
 	if expression == nil {
 		return nil, nil
@@ -0,0 +1,84 @@
+package protocol
+
+// The inlay hint types are introduced in LSP 3.17.0, which is newer than the generated protocol.
+
+/**
+ * Inlay hint kinds.
+ *
+ * @since 3.17.0
+ */
+type InlayHintKind float64
+
+const (
+	/**
+	 * An inlay hint that is for a type annotation.
+	 */
+	InlayHintKindType InlayHintKind = 1
+	/**
+	 * An inlay hint that is for a parameter.
+	 */
+	InlayHintKindParameter InlayHintKind = 2
+)
+
+/**
+ * Inlay hint information.
+ *
+ * @since 3.17.0
+ */
+type InlayHint struct {
+	/**
+	 * The position of this hint.
+	 */
+	Position Position `json:"position"`
+	/**
+	 * The label of this hint.
+	 */
+	Label string `json:"label"`
+	/**
+	 * The kind of this hint.
+	 */
+	Kind InlayHintKind `json:"kind,omitempty"`
+	/**
+	 * The tooltip text when you hover over this item.
+	 */
+	Tooltip string `json:"tooltip,omitempty"`
+	/**
+	 * Render padding before the hint.
+	 */
+	PaddingLeft bool `json:"paddingLeft,omitempty"`
+	/**
+	 * Render padding after the hint.
+	 */
+	PaddingRight bool `json:"paddingRight,omitempty"`
+}
+
+/**
+ * A parameter literal used in inlay hint requests.
+ *
+ * @since 3.17.0
+ */
+type InlayHintParams struct {
+	/**
+	 * The text document.
+	 */
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	/**
+	 * The document range for which inlay hints should be computed.
+	 */
+	Range Range `json:"range"`
+	WorkDoneProgressParams
+}
+
+/**
+ * Inlay hint options used during static registration.
+ *
+ * @since 3.17.0
+ */
+type InlayHintOptions struct {
+	/**
+	 * The server provides support to resolve additional
+	 * information for an inlay hint item.
+	 */
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+	WorkDoneProgressOptions
+}
@@ -3692,6 +3692,12 @@ type ServerCapabilities struct {
 	 * @since 3.16.0
 	 */
 	MonikerProvider interface{}/* bool | MonikerOptions | MonikerRegistrationOptions*/ `json:"monikerProvider,omitempty"`
+	/**
+	 * The server provides inlay hints.
+	 *
+	 * @since 3.17.0
+	 */
+	InlayHintProvider interface{}/* bool | InlayHintOptions */ `json:"inlayHintProvider,omitempty"`
 	/**
 	 * Experimental server capabilities.
 	 */
@@ -0,0 +1,86 @@
+// Package rules defines the rules, the severities and the options of the validation, it's shared by the
+// validation and the settings which configure it
+package rules
+
+import "github.com/hashicorp/hcl/v2"
+
+// Rule is a kind of diagnostics reported by the validation, the code and name are stable,
+// they're used in the suppression comments and the severity settings
+type Rule struct {
+	// Code is like AZ001
+	Code string
+	// Name is like unknown-property
+	Name string
+	// Severity is the default severity of the diagnostics
+	Severity hcl.DiagnosticSeverity
+}
+
+var (
+	UnknownProperty         = Rule{Code: "AZ001", Name: "unknown-property", Severity: hcl.DiagError}
+	ReadOnlyProperty        = Rule{Code: "AZ002", Name: "read-only-property", Severity: hcl.DiagError}
+	MissingRequiredProperty = Rule{Code: "AZ003", Name: "missing-required-property", Severity: hcl.DiagError}
+	InvalidValue            = Rule{Code: "AZ004", Name: "invalid-value", Severity: hcl.DiagError}
+	ValueConstraint         = Rule{Code: "AZ005", Name: "value-constraint", Severity: hcl.DiagError}
+	UnknownResourceType     = Rule{Code: "AZ006", Name: "unknown-resource-type", Severity: hcl.DiagError}
+	UnknownApiVersion       = Rule{Code: "AZ007", Name: "unknown-api-version", Severity: hcl.DiagError}
+	PropertyConstraint      = Rule{Code: "AZ008", Name: "property-constraint", Severity: hcl.DiagError}
+	ParentScope             = Rule{Code: "AZ009", Name: "parent-scope", Severity: hcl.DiagWarning}
+	SensitiveProperty       = Rule{Code: "AZ010", Name: "sensitive-property", Severity: hcl.DiagWarning}
+	PreviewApiVersion       = Rule{Code: "AZ011", Name: "preview-api-version", Severity: hcl.DiagWarning}
+	OutdatedApiVersion      = Rule{Code: "AZ012", Name: "outdated-api-version", Severity: hcl.DiagWarning}
+	DuplicateDeclaration    = Rule{Code: "AZ013", Name: "duplicate-declaration", Severity: hcl.DiagError}
+)
+
+// All are the rules of the validation, ordered by their codes
+var All = []Rule{
+	UnknownProperty,
+	ReadOnlyProperty,
+	MissingRequiredProperty,
+	InvalidValue,
+	ValueConstraint,
+	UnknownResourceType,
+	UnknownApiVersion,
+	PropertyConstraint,
+	ParentScope,
+	SensitiveProperty,
+	PreviewApiVersion,
+	OutdatedApiVersion,
+	DuplicateDeclaration,
+}
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityOff     = "off"
+)
+
+// Options configures the validation
+type Options struct {
+	// Severities overrides the default severities of the rules, the keys are the rule codes or names,
+	// and the values are SeverityError, SeverityWarning or SeverityOff
+	Severities map[string]string
+	// ApiVersionPolicy configures which api-versions are reported
+	ApiVersionPolicy ApiVersionPolicy
+	// DisabledProviders are the providers whose blocks aren't validated, e.g. azurerm
+	DisabledProviders map[string]bool
+}
+
+// DefaultOptions returns the options which use the default severities of the rules
+func DefaultOptions() Options {
+	return Options{
+		ApiVersionPolicy: DefaultApiVersionPolicy,
+	}
+}
+
+// ApiVersionPolicy configures which api-versions in the `type` attribute are reported
+type ApiVersionPolicy struct {
+	// Preview reports the preview api-versions if there's a stable api-version which is released at the same time or later
+	Preview bool
+	// MaxNewerVersions reports the api-versions which have at least this number of newer api-versions, 0 disables the check
+	MaxNewerVersions int
+}
+
+// DefaultApiVersionPolicy only reports the preview api-versions which have a stable replacement
+var DefaultApiVersionPolicy = ApiVersionPolicy{
+	Preview: true,
+}
@@ -0,0 +1,182 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Azure/ms-terraform-lsp/internal/rules"
+	"strings"
+)
+
+// Settings are configured by the client in the initialization options and the workspace/didChangeConfiguration
+// notifications, e.g.
+//
+//	{
+//	  "validation": {"azurerm": false},
+//	  "diagnostics": {"severity": {"AZ001": "warning", "sensitive-property": "off"}},
+//	  "offline": true,
+//	  "terraformPath": "/usr/local/bin/terraform",
+//	  "telemetry": {"enabled": false},
+//	  "apiVersion": {"preview": true, "maxNewerVersions": 3},
+//	  "folders": {"platform": {"validation": {"azurerm": true}}}
+//	}
+//
+// The options which aren't specified keep their default values.
+type Settings struct {
+	Validation  Validation  `json:"validation"`
+	Diagnostics Diagnostics `json:"diagnostics"`
+	// Offline disables the requests to the internet, the bundled data is used instead
+	Offline bool `json:"offline"`
+	// TerraformPath is the path to the terraform executable, it's searched in PATH if it's empty.
+	// The aztfmigrate command always searches PATH, because the executable is found by the aztfmigrate module.
+	TerraformPath string     `json:"terraformPath"`
+	Telemetry     Telemetry  `json:"telemetry"`
+	ApiVersion    ApiVersion `json:"apiVersion"`
+	// Folders overrides the settings in the workspace folders, the keys are the names or the paths of the folders
+	Folders map[string]json.RawMessage `json:"folders,omitempty"`
+}
+
+// Validation turns the schema validation on or off per provider
+type Validation struct {
+	Azapi   bool `json:"azapi"`
+	Azurerm bool `json:"azurerm"`
+	Msgraph bool `json:"msgraph"`
+}
+
+// Diagnostics configures the diagnostics of the schema validation
+type Diagnostics struct {
+	// Severity overrides the severities of the rules, the keys are the rule codes or names,
+	// and the values are error, warning or off
+	Severity map[string]string `json:"severity"`
+}
+
+type Telemetry struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ApiVersion configures which api-versions of the azapi resources are reported
+type ApiVersion struct {
+	// Preview reports the preview api-versions which have a stable replacement
+	Preview bool `json:"preview"`
+	// MaxNewerVersions reports the api-versions which have at least this number of newer api-versions, 0 disables it
+	MaxNewerVersions int `json:"maxNewerVersions"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Validation: Validation{
+			Azapi:   true,
+			Azurerm: true,
+			Msgraph: true,
+		},
+		Telemetry: Telemetry{
+			Enabled: true,
+		},
+		ApiVersion: ApiVersion{
+			Preview:          rules.DefaultApiVersionPolicy.Preview,
+			MaxNewerVersions: rules.DefaultApiVersionPolicy.MaxNewerVersions,
+		},
+	}
+}
+
+// DecodeSettings decodes the settings sent by the client, the default settings are returned if options is nil
+func DecodeSettings(options interface{}) (Settings, error) {
+	settings := DefaultSettings()
+	if options == nil {
+		return settings, nil
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		return DefaultSettings(), err
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return DefaultSettings(), fmt.Errorf("invalid settings: %w", err)
+	}
+	if err := settings.Validate(); err != nil {
+		return DefaultSettings(), err
+	}
+	return settings, nil
+}
+
+// Validate checks the rules and the severities in the severity overrides, and the api-version policy
+func (s Settings) Validate() error {
+	for key, severity := range s.Diagnostics.Severity {
+		known := false
+		for _, rule := range rules.All {
+			if key == rule.Code || key == rule.Name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("invalid settings: unknown rule %q in diagnostics.severity", key)
+		}
+		switch strings.ToLower(severity) {
+		case rules.SeverityError, rules.SeverityWarning, rules.SeverityOff:
+		default:
+			return fmt.Errorf("invalid settings: severity of %q must be one of %q, %q or %q, but got %q",
+				key, rules.SeverityError, rules.SeverityWarning, rules.SeverityOff, severity)
+		}
+	}
+	if s.ApiVersion.MaxNewerVersions < 0 {
+		return fmt.Errorf("invalid settings: apiVersion.maxNewerVersions must not be negative, but got %d", s.ApiVersion.MaxNewerVersions)
+	}
+	for key, override := range s.Folders {
+		folderSettings, err := s.override(override)
+		if err != nil {
+			return fmt.Errorf("invalid settings of folder %q: %w", key, err)
+		}
+		if err := folderSettings.Validate(); err != nil {
+			return fmt.Errorf("invalid settings of folder %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// ForFolder returns the settings in the workspace folder, the settings are returned as they are if the folder
+// doesn't override them. The overrides are checked by Validate, the invalid ones are ignored here.
+func (s Settings) ForFolder(name, path string) Settings {
+	override, ok := s.Folders[name]
+	if !ok {
+		override, ok = s.Folders[path]
+	}
+	if !ok {
+		return s
+	}
+	res, err := s.override(override)
+	if err != nil {
+		return s
+	}
+	return res
+}
+
+// override decodes the folder settings over a copy of the settings, the folder settings can't override the folders
+func (s Settings) override(data json.RawMessage) (Settings, error) {
+	res := s
+	res.Folders = nil
+	// the severities are merged, the map is copied so the settings aren't changed
+	res.Diagnostics.Severity = make(map[string]string, len(s.Diagnostics.Severity))
+	for key, severity := range s.Diagnostics.Severity {
+		res.Diagnostics.Severity[key] = severity
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return s, err
+	}
+	res.Folders = nil
+	return res, nil
+}
+
+// ValidationOptions returns the options of the schema validation
+func (s Settings) ValidationOptions() rules.Options {
+	return rules.Options{
+		Severities: s.Diagnostics.Severity,
+		ApiVersionPolicy: rules.ApiVersionPolicy{
+			Preview:          s.ApiVersion.Preview,
+			MaxNewerVersions: s.ApiVersion.MaxNewerVersions,
+		},
+		DisabledProviders: map[string]bool{
+			"azapi":   !s.Validation.Azapi,
+			"azurerm": !s.Validation.Azurerm,
+			"msgraph": !s.Validation.Msgraph,
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package settings
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeSettings_nil(t *testing.T) {
+	s, err := DecodeSettings(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(DefaultSettings(), s); diff != "" {
+		t.Fatalf("unexpected settings: %s", diff)
+	}
+}
+
+func TestDecodeSettings_partial(t *testing.T) {
+	var options interface{}
+	err := json.Unmarshal([]byte(`{
+  "validation": {"azurerm": false},
+  "diagnostics": {"severity": {"AZ001": "warning", "sensitive-property": "off"}},
+  "offline": true,
+  "terraformPath": "/usr/local/bin/terraform",
+  "telemetry": {"enabled": false},
+  "apiVersion": {"maxNewerVersions": 3}
+}`), &options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := DecodeSettings(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Settings{
+		Validation: Validation{
+			Azapi:   true,
+			Azurerm: false,
+			Msgraph: true,
+		},
+		Diagnostics: Diagnostics{
+			Severity: map[string]string{"AZ001": "warning", "sensitive-property": "off"},
+		},
+		Offline:       true,
+		TerraformPath: "/usr/local/bin/terraform",
+		Telemetry: Telemetry{
+			Enabled: false,
+		},
+		ApiVersion: ApiVersion{
+			Preview:          true,
+			MaxNewerVersions: 3,
+		},
+	}
+	if diff := cmp.Diff(expected, s); diff != "" {
+		t.Fatalf("unexpected settings: %s", diff)
+	}
+
+	opts := s.ValidationOptions()
+	if !opts.DisabledProviders["azurerm"] || opts.DisabledProviders["azapi"] || opts.DisabledProviders["msgraph"] {
+		t.Errorf("expect only azurerm validation is disabled, but got %v", opts.DisabledProviders)
+	}
+	if opts.ApiVersionPolicy.MaxNewerVersions != 3 || !opts.ApiVersionPolicy.Preview {
+		t.Errorf("unexpected api-version policy: %+v", opts.ApiVersionPolicy)
+	}
+}
+
+func TestSettings_ForFolder(t *testing.T) {
+	var options interface{}
+	err := json.Unmarshal([]byte(`{
+  "diagnostics": {"severity": {"AZ001": "warning"}},
+  "folders": {
+    "platform": {"validation": {"azurerm": false}, "diagnostics": {"severity": {"AZ002": "off"}}},
+    "/workspace/app": {"offline": true}
+  }
+}`), &options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := DecodeSettings(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	platform := s.ForFolder("platform", "/workspace/platform")
+	if platform.Validation.Azurerm || !platform.Validation.Azapi || platform.Folders != nil {
+		t.Errorf("unexpected platform settings: %+v", platform)
+	}
+	if diff := cmp.Diff(map[string]string{"AZ001": "warning", "AZ002": "off"}, platform.Diagnostics.Severity); diff != "" {
+		t.Errorf("expect the severities are merged: %s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"AZ001": "warning"}, s.Diagnostics.Severity); diff != "" {
+		t.Errorf("expect the workspace severities aren't changed: %s", diff)
+	}
+
+	if app := s.ForFolder("app", "/workspace/app"); !app.Offline {
+		t.Error("expect the folder settings are found by the path")
+	}
+	if diff := cmp.Diff(s, s.ForFolder("landing-zone", "/workspace/landing-zone")); diff != "" {
+		t.Errorf("expect the workspace settings: %s", diff)
+	}
+}
+
+func TestDecodeSettings_invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options map[string]interface{}
+	}{
+		{
+			name:    "unknown rule",
+			options: map[string]interface{}{"diagnostics": map[string]interface{}{"severity": map[string]interface{}{"AZ999": "off"}}},
+		},
+		{
+			name:    "invalid severity",
+			options: map[string]interface{}{"diagnostics": map[string]interface{}{"severity": map[string]interface{}{"AZ001": "info"}}},
+		},
+		{
+			name:    "invalid type",
+			options: map[string]interface{}{"offline": "yes"},
+		},
+		{
+			name:    "invalid folder settings",
+			options: map[string]interface{}{"folders": map[string]interface{}{"platform": map[string]interface{}{"offline": "yes"}}},
+		},
+		{
+			name:    "invalid severity in folder settings",
+			options: map[string]interface{}{"folders": map[string]interface{}{"platform": map[string]interface{}{"diagnostics": map[string]interface{}{"severity": map[string]interface{}{"AZ001": "info"}}}}},
+		},
+		{
+			name:    "negative max newer versions",
+			options: map[string]interface{}{"apiVersion": map[string]interface{}{"maxNewerVersions": -1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := DecodeSettings(tc.options)
+			if err == nil {
+				t.Fatalf("expect an error, but got settings %+v", s)
+			}
+			if diff := cmp.Diff(DefaultSettings(), s); diff != "" {
+				t.Fatalf("expect the default settings: %s", diff)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tf
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -20,8 +21,14 @@ type Terraform struct {
 	workingDirectory string
 }
 
-// FindTerraform finds the path to the terraform executable.
-func FindTerraform(ctx context.Context) (string, error) {
+// FindTerraform finds the path to the terraform executable, the execPath is used if it's specified.
+func FindTerraform(ctx context.Context, execPath string) (string, error) {
+	if execPath != "" {
+		if _, err := os.Stat(execPath); err != nil {
+			return "", fmt.Errorf("terraform executable %q is not found: %w", execPath, err)
+		}
+		return execPath, nil
+	}
 	i := install.NewInstaller()
 	return i.Ensure(ctx, []src.Source{
 		&fs.Version{
@@ -31,8 +38,8 @@ func FindTerraform(ctx context.Context) (string, error) {
 	})
 }
 
-func NewTerraform(workingDirectory string, logEnabled bool) (*Terraform, error) {
-	execPath, err := FindTerraform(context.Background())
+func NewTerraform(workingDirectory string, execPath string, logEnabled bool) (*Terraform, error) {
+	execPath, err := FindTerraform(context.Background(), execPath)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,146 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/Azure/ms-terraform-lsp/internal/module"
+	"github.com/Azure/ms-terraform-lsp/internal/pathcmp"
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
+)
+
+// terraformDataDir is the directory created by terraform init in the working directory
+const terraformDataDir = ".terraform"
+
+// Folder is a workspace folder opened in the client, the modules in the folder are indexed in its own index
+type Folder struct {
+	// Name is the name of the folder in the client, it's used to look up the folder settings
+	Name  string
+	Path  string
+	Index *module.Index
+}
+
+// Folders tracks the workspace folders of the session. A path belongs to the innermost folder which contains it,
+// the paths which aren't in any folder share a fallback index, e.g. when a single file is opened.
+type Folders struct {
+	fsys     module.FS
+	fallback *module.Index
+
+	mu      sync.RWMutex
+	folders []*Folder
+}
+
+func NewFolders(fsys module.FS) *Folders {
+	return &Folders{
+		fsys:     fsys,
+		fallback: module.NewIndex(fsys),
+		folders:  make([]*Folder, 0),
+	}
+}
+
+// Add adds the folder, the existing folder is returned with false if the folder at the path is already added
+func (f *Folders) Add(name, path string) (*Folder, bool) {
+	path = filepath.Clean(path)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, folder := range f.folders {
+		if pathcmp.PathEquals(folder.Path, path) {
+			return folder, false
+		}
+	}
+	folder := &Folder{
+		Name:  name,
+		Path:  path,
+		Index: module.NewIndex(f.fsys),
+	}
+	f.folders = append(f.folders, folder)
+	sort.Slice(f.folders, func(i, j int) bool {
+		return f.folders[i].Path < f.folders[j].Path
+	})
+	return folder, true
+}
+
+// Remove removes the folder at the path, its index is dropped with it. It returns nil if there's no such folder.
+func (f *Folders) Remove(path string) *Folder {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, folder := range f.folders {
+		if pathcmp.PathEquals(folder.Path, path) {
+			f.folders = append(f.folders[:i:i], f.folders[i+1:]...)
+			return folder
+		}
+	}
+	return nil
+}
+
+// List returns the folders ordered by their paths
+func (f *Folders) List() []*Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]*Folder{}, f.folders...)
+}
+
+// FolderOf returns the innermost folder which contains the path, or nil if the path isn't in any folder
+func (f *Folders) FolderOf(path string) *Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var res *Folder
+	for _, folder := range f.folders {
+		if contains(folder.Path, path) && (res == nil || len(folder.Path) > len(res.Path)) {
+			res = folder
+		}
+	}
+	return res
+}
+
+// Index returns the module index of the folder which contains the path
+func (f *Folders) Index(path string) *module.Index {
+	if folder := f.FolderOf(path); folder != nil {
+		return folder.Index
+	}
+	return f.fallback
+}
+
+// Settings returns the settings of the path, the settings of its folder override the base settings
+func (f *Folders) Settings(base settings.Settings, path string) settings.Settings {
+	if folder := f.FolderOf(path); folder != nil {
+		return base.ForFolder(folder.Name, folder.Path)
+	}
+	return base
+}
+
+// WorkingDirectory returns the Terraform working directory of the module in the directory. It's the nearest
+// directory initialized by terraform init, which is searched from the directory up to the root of its folder.
+// The directory itself is returned if it isn't in any folder or no initialized directory is found.
+func (f *Folders) WorkingDirectory(dir string) string {
+	dir = filepath.Clean(dir)
+	folder := f.FolderOf(dir)
+	if folder == nil {
+		return dir
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		if info, err := os.Stat(filepath.Join(current, terraformDataDir)); err == nil && info.IsDir() {
+			return current
+		}
+		if pathcmp.PathEquals(current, folder.Path) || filepath.Dir(current) == current {
+			return dir
+		}
+	}
+}
+
+// contains returns true if the path is the root directory or in it
+func contains(root, path string) bool {
+	for current := filepath.Clean(path); ; current = filepath.Dir(current) {
+		if pathcmp.PathEquals(current, root) {
+			return true
+		}
+		if filepath.Dir(current) == current {
+			return false
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/ms-terraform-lsp/internal/settings"
+)
+
+type osFS struct{}
+
+func (osFS) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func Test_Folders(t *testing.T) {
+	root := t.TempDir()
+	landingZone := filepath.Join(root, "landing-zone")
+	platform := filepath.Join(root, "landing-zone", "platform")
+	for _, dir := range []string{filepath.Join(landingZone, "modules", "network"), filepath.Join(platform, ".terraform")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	folders := NewFolders(osFS{})
+	if _, ok := folders.Add("landing-zone", landingZone); !ok {
+		t.Fatal("expected the folder is added")
+	}
+	if _, ok := folders.Add("platform", platform); !ok {
+		t.Fatal("expected the folder is added")
+	}
+	if _, ok := folders.Add("platform", platform+string(filepath.Separator)); ok {
+		t.Error("expected the folder isn't added twice")
+	}
+	if folders := folders.List(); len(folders) != 2 {
+		t.Fatalf("expected 2 folders, got %d", len(folders))
+	}
+
+	// the path belongs to the innermost folder
+	if folder := folders.FolderOf(filepath.Join(platform, "main.tf")); folder == nil || folder.Name != "platform" {
+		t.Errorf("expected the platform folder, got %v", folder)
+	}
+	if folder := folders.FolderOf(filepath.Join(landingZone, "modules", "network")); folder == nil || folder.Name != "landing-zone" {
+		t.Errorf("expected the landing-zone folder, got %v", folder)
+	}
+	if folder := folders.FolderOf(root); folder != nil {
+		t.Errorf("expected no folder, got %v", folder)
+	}
+	if folders.Index(landingZone) == folders.Index(platform) || folders.Index(root) != folders.Index(filepath.Join(root, "app")) {
+		t.Error("expected an index per folder and a shared index outside the folders")
+	}
+
+	// the working directory is the nearest initialized directory in the folder
+	if dir := folders.WorkingDirectory(filepath.Join(platform, "modules", "app")); dir != platform {
+		t.Errorf("expected %q, got %q", platform, dir)
+	}
+	network := filepath.Join(landingZone, "modules", "network")
+	if dir := folders.WorkingDirectory(network); dir != network {
+		t.Errorf("expected %q, got %q", network, dir)
+	}
+
+	// the settings of the folder override the base settings
+	base := settings.DefaultSettings()
+	base.Folders = map[string]json.RawMessage{"platform": json.RawMessage(`{"offline": true}`)}
+	if s := folders.Settings(base, filepath.Join(platform, "main.tf")); !s.Offline {
+		t.Error("expected the platform folder is offline")
+	}
+	if s := folders.Settings(base, filepath.Join(landingZone, "main.tf")); s.Offline {
+		t.Error("expected the landing-zone folder isn't offline")
+	}
+
+	// the paths in the removed folder belong to the parent folder
+	if folders.Remove(platform) == nil {
+		t.Fatal("expected the folder is removed")
+	}
+	if folder := folders.FolderOf(filepath.Join(platform, "main.tf")); folder == nil || folder.Name != "landing-zone" {
+		t.Errorf("expected the landing-zone folder, got %v", folder)
+	}
+	if folders.Remove(platform) != nil {
+		t.Error("expected the folder isn't removed twice")
+	}
+}